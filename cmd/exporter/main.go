@@ -5,20 +5,47 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
-	//"beseller-yml-exporter/internal/domain/repository"
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/domain/repository"
+	"beseller-yml-exporter/internal/infrastructure/avito"
+	"beseller-yml-exporter/internal/infrastructure/categorymap"
 	"beseller-yml-exporter/internal/infrastructure/config"
+	"beseller-yml-exporter/internal/infrastructure/feedtemplate"
 	"beseller-yml-exporter/internal/infrastructure/graphql"
+	"beseller-yml-exporter/internal/infrastructure/kits"
+	"beseller-yml-exporter/internal/infrastructure/linkcheck"
+	"beseller-yml-exporter/internal/infrastructure/mapping"
+	"beseller-yml-exporter/internal/infrastructure/meta"
+	"beseller-yml-exporter/internal/infrastructure/notify"
+	"beseller-yml-exporter/internal/infrastructure/onliner"
+	"beseller-yml-exporter/internal/infrastructure/output"
+	"beseller-yml-exporter/internal/infrastructure/publish"
+	"beseller-yml-exporter/internal/infrastructure/report"
+	"beseller-yml-exporter/internal/infrastructure/reviews"
+	"beseller-yml-exporter/internal/infrastructure/shopby"
+	"beseller-yml-exporter/internal/infrastructure/sitemap"
+	"beseller-yml-exporter/internal/infrastructure/urlbuilder"
 	"beseller-yml-exporter/internal/infrastructure/yml"
 	"beseller-yml-exporter/internal/logger"
 	"beseller-yml-exporter/internal/usecase"
 	"beseller-yml-exporter/internal/usecase/dto"
 )
 
+// Команды приложения
+const (
+	commandExport            = "export"
+	commandCheckLinks        = "check-links"
+	commandSuggestCategories = "suggest-categories"
+)
+
 func main() {
-	// Парсинг флагов командной строки
-	cfg := parseFlags()
+	// Команда (по умолчанию export) и флаги командной строки
+	command, args := parseCommand(os.Args[1:])
+	cfg := parseFlags(args)
 
 	// Инициализация логгера
 	log := logger.New(cfg.LogLevel)
@@ -26,30 +53,242 @@ func main() {
 
 	// Инициализация инфраструктуры
 	ctx := context.Background()
+	started := time.Now().UTC()
+
+	// Уведомления загружаются первыми, чтобы сообщить и об ошибках подготовки экспорта
+	var notifyUC *usecase.NotifyRunUseCase
+	var notifyCfg *notify.Config
+	if command == commandExport {
+		var err error
+		if notifyUC, notifyCfg, err = loadNotifications(cfg, log); err != nil {
+			log.Error("Invalid notifications configuration", "error", err)
+			os.Exit(2)
+		}
+	}
+
+	// setupFailed завершает работу при ошибке подготовки, отправляя уведомление о сбое
+	setupFailed := func(msg string, err error) {
+		log.Error(msg, "error", err)
+		if notifyUC != nil {
+			failed := &dto.ExportReport{
+				StartedAt:  started,
+				FinishedAt: time.Now().UTC(),
+				Feeds:      []dto.FeedReport{},
+				Error:      fmt.Sprintf("%s: %v", msg, err),
+			}
+			if notifyErr := notifyUC.Execute(ctx, dto.NotifyRequest{Report: failed, Routes: notifyCfg.Routes}); notifyErr != nil {
+				log.Error("Failed to send notifications", "error", notifyErr)
+			}
+		}
+		os.Exit(2)
+	}
 
 	// GraphQL клиент и репозиторий
 	log.Info("Connecting to GraphQL endpoint")
 	gqlClient := graphql.NewClient(cfg.GraphQLEndpoint, cfg.HTTPTimeout, log)
-	catalogRepo := graphql.NewCatalogRepository(gqlClient, log, cfg.ShopURL)
 
-	// YML writer
-	ymlWriter := yml.NewWriter(log)
+	// Построитель URL товаров
+	productURLs, err := urlbuilder.NewProductURLBuilder(cfg.ShopURL, urlbuilder.Config{
+		Strategy:      urlbuilder.Strategy(cfg.ProductURLStrategy),
+		Template:      cfg.ProductURLTemplate,
+		TrailingSlash: cfg.ProductURLTrailingSlash,
+	})
+	if err != nil {
+		setupFailed("Invalid product URL settings", err)
+	}
+
+	// Построитель URL изображений
+	imageURLs, err := urlbuilder.NewImageURLBuilder(cfg.ShopURL, urlbuilder.ImageConfig{
+		Template:    cfg.ImageURLTemplate,
+		CDNBaseURL:  cfg.ImageCDNURL,
+		IncludeMeta: cfg.ImageIncludeMeta,
+	})
+	if err != nil {
+		setupFailed("Invalid image URL settings", err)
+	}
+
+	// Маппинг полей BeSeller на атрибуты товара
+	mappingCfg, err := mapping.LoadConfig(cfg.FieldMappingPath)
+	if err != nil {
+		setupFailed("Invalid field mapping", err)
+	}
+	fieldMapper, err := mapping.NewMapper(mappingCfg)
+	if err != nil {
+		setupFailed("Invalid field mapping", err)
+	}
+
+	// Единицы скидок промокодов по ID типа скидки
+	discountUnits, err := parseDiscountUnits(cfg.DiscountPercentTypes, cfg.DiscountCurrencyTypes)
+	if err != nil {
+		setupFailed("Invalid discount types", err)
+	}
+
+	catalogRepo := graphql.NewCatalogRepository(gqlClient, log, productURLs, imageURLs, fieldMapper, discountUnits, cfg.ReviewerIDSalt)
+
+	// Комплекты товаров: в API нет запроса ProductKit, поэтому они читаются из файла
+	var kitRepo repository.KitRepository
+	if cfg.KitsPath != "" {
+		kitRepo = kits.NewFileRepository(cfg.KitsPath)
+	}
+
+	// Соответствие категорий BeSeller категориям площадок (общее для writer'ов)
+	categoryMap, err := categorymap.Load(cfg.CategoryMapPath)
+	if err != nil {
+		setupFailed("Invalid category map", err)
+	}
+
+	if command == commandSuggestCategories {
+		taxonomy, err := categorymap.LoadTaxonomy(cfg.TaxonomyPath, cfg.TaxonomyKey)
+		if err != nil {
+			setupFailed("Invalid taxonomy", err)
+		}
+		suggester := categorymap.NewSuggester(categoryMap, taxonomy, cfg.TaxonomyKey, cfg.SuggestMinScore)
+		suggestUC := usecase.NewSuggestCategoriesUseCase(catalogRepo, suggester, log)
+		if err := suggestUC.Execute(ctx, dto.SuggestCategoriesRequest{OutputPath: cfg.SuggestPath}); err != nil {
+			log.Error("Category suggestion failed", "error", err)
+			os.Exit(1)
+		}
+		log.Info("Category suggestion completed successfully")
+		return
+	}
+
+	// YML writer; market_category — из таблицы соответствия
+	ymlWriter := yml.NewWriter(log, yml.Options{MaxPictures: cfg.YMLMaxPictures, Categories: categoryMap})
+
+	// Shop.by writer: режим классификации и обязательные атрибуты типов
+	shopByAttributes, err := shopby.LoadRequiredAttributes(cfg.ShopByAttributesPath)
+	if err != nil {
+		setupFailed("Invalid shop.by attributes", err)
+	}
+	shopByWriter, err := shopby.NewWriter(log, shopby.Options{
+		Classification:     shopby.ClassificationMode(cfg.ShopByClassification),
+		RequiredAttributes: shopByAttributes,
+		MaxPictures:        cfg.YMLMaxPictures,
+	})
+	if err != nil {
+		setupFailed("Invalid shop.by settings", err)
+	}
+
+	// Avito writer: контакты и адрес магазина
+	avitoWriter := avito.NewWriter(log, avito.Options{
+		ContactPhone: cfg.AvitoContactPhone,
+		Address:      cfg.AvitoAddress,
+		ManagerName:  cfg.AvitoManagerName,
+		MaxImages:    cfg.AvitoMaxImages,
+		Categories:   categoryMap,
+	})
+
+	// Диалекты YML площадок
+	rozetkaWriter := yml.NewWriter(log, yml.Options{
+		MaxPictures:    cfg.YMLMaxPictures,
+		Profile:        yml.Rozetka,
+		RequiredParams: splitList(cfg.RozetkaRequiredParams),
+	})
+	kaspiWriter := yml.NewWriter(log, yml.Options{
+		Profile:    yml.Kaspi,
+		MerchantID: cfg.KaspiMerchantID,
+		StoreIDs:   splitList(cfg.KaspiStoreIDs),
+	})
+
+	// Проверка доступности ссылок
+	linkChecker := linkcheck.NewChecker(linkcheck.Config{
+		Concurrency:    cfg.LinkCheckConcurrency,
+		Timeout:        cfg.LinkCheckTimeout,
+		MinImageWidth:  cfg.LinkCheckMinImageWidth,
+		MinImageHeight: cfg.LinkCheckMinImageHeight,
+		CachePath:      cfg.LinkCheckCachePath,
+		CacheTTL:       cfg.LinkCheckCacheTTL,
+	}, log)
+
+	if command == commandCheckLinks {
+		checkUC := usecase.NewCheckLinksUseCase(catalogRepo, linkChecker, log)
+		req := dto.CheckLinksRequest{
+			StatusID:   cfg.StatusID,
+			ReportPath: cfg.LinkReportPath,
+		}
+		if err := checkUC.Execute(ctx, req); err != nil {
+			log.Error("Link check failed", "error", err)
+			os.Exit(1)
+		}
+		log.Info("Link check completed successfully")
+		return
+	}
 
 	// Инициализация use case
-	exportUC := usecase.NewExportCatalogUseCase(catalogRepo, ymlWriter, log)
+	writers := map[string]usecase.CatalogWriter{
+		"yml":            ymlWriter,
+		"google_reviews": reviews.NewWriter(log),
+		"onliner":        onliner.NewWriter(log),
+		"shopby":         shopByWriter,
+		"meta_csv":       meta.NewWriter(log, meta.Options{Format: meta.FormatCSV, Categories: categoryMap}),
+		"meta_xml":       meta.NewWriter(log, meta.Options{Format: meta.FormatXML, Categories: categoryMap}),
+		"avito":          avitoWriter,
+		"rozetka":        rozetkaWriter,
+		"prom":           yml.NewWriter(log, yml.Options{MaxPictures: cfg.YMLMaxPictures, Profile: yml.PromUA}),
+		"kaspi":          kaspiWriter,
+		"sitemap":        sitemap.NewWriter(log, sitemap.Options{BaseURL: cfg.SitemapBaseURL}),
+	}
+
+	// Фиды: из FEEDS_CONFIG или один фид из --out; фиды по шаблонам получают собственные writer'ы,
+	// публикаторы создаются из раздела publishers того же файла
+	publishers := map[string]usecase.Publisher{}
+	feeds, err := loadFeeds(cfg, log, writers, publishers)
+	if err != nil {
+		setupFailed("Invalid feeds configuration", err)
+	}
+	exportUC := usecase.NewExportCatalogUseCase(catalogRepo, kitRepo, writers, urlbuilder.NewTrackingDecorator(), linkChecker, output.NewSplitter(log), output.NewInspector(), publishers, log)
 
 	// Подготовка запроса на экспорт
 	req := dto.ExportRequest{
-		OutputPath:  cfg.OutputPath,
-		ShopName:    cfg.ShopName,
-		ShopCompany: cfg.ShopCompany,
-		ShopURL:     cfg.ShopURL,
-		Currency:    cfg.Currency,
-		StatusID:    cfg.StatusID,
+		Feeds:          feeds,
+		ShopName:       cfg.ShopName,
+		ShopCompany:    cfg.ShopCompany,
+		ShopURL:        cfg.ShopURL,
+		Currency:       cfg.Currency,
+		StatusID:       cfg.StatusID,
+		LinkCheckMode:  dto.LinkCheckMode(cfg.LinkCheckMode),
+		LinkReportPath: cfg.LinkReportPath,
+	}
+
+	// Отчёт предыдущего запуска нужен уведомлениям для сравнения числа предложений
+	var previousReport *dto.ExportReport
+	if notifyUC != nil && cfg.ReportPath != "" {
+		var previous dto.ExportReport
+		if ok, err := report.ReadJSON(cfg.ReportPath, &previous); err != nil {
+			log.Warn("Failed to read previous run report", "error", err)
+		} else if ok {
+			previousReport = &previous
+		}
 	}
 
 	// Выполнение экспорта
-	if err := exportUC.Execute(ctx, req); err != nil {
+	runReport, err := exportUC.Execute(ctx, req)
+
+	// Отчёт о запуске сохраняется и при ошибке
+	if cfg.ReportPath != "" {
+		if reportErr := report.WriteJSON(cfg.ReportPath, runReport); reportErr != nil {
+			log.Error("Failed to write run report", "error", reportErr)
+		} else {
+			log.Info(fmt.Sprintf("Run report created: %s", cfg.ReportPath))
+		}
+	}
+
+	// Уведомления отправляются и при ошибке экспорта
+	if notifyUC != nil {
+		notifyErr := notifyUC.Execute(ctx, dto.NotifyRequest{
+			Report:              runReport,
+			Previous:            previousReport,
+			Routes:              notifyCfg.Routes,
+			MaxOfferDropPercent: notifyCfg.MaxOfferDropPercent,
+			MaxSkipped:          notifyCfg.MaxSkipped,
+			MaxSkippedPercent:   notifyCfg.MaxSkippedPercent,
+		})
+		if notifyErr != nil {
+			log.Error("Failed to send notifications", "error", notifyErr)
+		}
+	}
+
+	if err != nil {
 		log.Error("Export failed", "error", err)
 		os.Exit(1)
 	}
@@ -57,7 +296,152 @@ func main() {
 	log.Info("Export completed successfully")
 }
 
-func parseFlags() *config.Config {
+// loadNotifications создаёт каналы уведомлений из NOTIFY_CONFIG;
+// без файла настроек уведомления не отправляются
+func loadNotifications(cfg *config.Config, log *logger.Logger) (*usecase.NotifyRunUseCase, *notify.Config, error) {
+	if cfg.NotifyConfigPath == "" {
+		return nil, nil, nil
+	}
+
+	notifyCfg, err := notify.LoadConfig(cfg.NotifyConfigPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	interval, err := notifyCfg.Interval()
+	if err != nil {
+		return nil, nil, err
+	}
+	state, err := notify.LoadState(notifyCfg.StatePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notifiers := make(map[string]usecase.Notifier, len(notifyCfg.Sinks))
+	for name, sc := range notifyCfg.Sinks {
+		sink, err := notify.New(name, sc, interval, state, log)
+		if err != nil {
+			return nil, nil, err
+		}
+		notifiers[name] = sink
+	}
+	return usecase.NewNotifyRunUseCase(notifiers, log), notifyCfg, nil
+}
+
+// loadFeeds возвращает фиды из файла конфигурации или фид по умолчанию.
+// Для фидов формата template шаблон разбирается сразу, а writer регистрируется
+// под форматом "template:<имя фида>"; публикаторы регистрируются по имени
+func loadFeeds(
+	cfg *config.Config,
+	log *logger.Logger,
+	writers map[string]usecase.CatalogWriter,
+	publishers map[string]usecase.Publisher,
+) ([]dto.FeedRequest, error) {
+	if cfg.FeedsConfigPath == "" {
+		return []dto.FeedRequest{{
+			Name:       "default",
+			Format:     "yml",
+			OutputPath: cfg.OutputPath,
+		}}, nil
+	}
+
+	file, err := config.LoadFeeds(cfg.FeedsConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, pc := range file.Publishers {
+		publisher, err := publish.New(name, pc, log)
+		if err != nil {
+			return nil, err
+		}
+		publishers[name] = publisher
+	}
+
+	feeds := make([]dto.FeedRequest, 0, len(file.Feeds))
+	for _, fc := range file.Feeds {
+		format := fc.Format
+		if format == config.FormatTemplate {
+			if fc.Template == "" {
+				return nil, fmt.Errorf("feed %s: template path is required for format %s", fc.Name, format)
+			}
+			writer, err := feedtemplate.NewWriter(log, fc.Template)
+			if err != nil {
+				return nil, fmt.Errorf("feed %s: %w", fc.Name, err)
+			}
+			format = config.FormatTemplate + ":" + fc.Name
+			writers[format] = writer
+		}
+
+		feeds = append(feeds, dto.FeedRequest{
+			Name:       fc.Name,
+			Format:     format,
+			OutputPath: fc.Output,
+			Tracking:   fc.Tracking,
+			Pricing:    fc.Pricing,
+			Currency:   fc.Currency,
+			Rates:      fc.Rates,
+
+			VendorModelCategories: fc.VendorModelCategories,
+			Delivery:              fc.Delivery,
+			Promos:                fc.Promos,
+			Kits:                  dto.KitMode(fc.Kits),
+			Ratings:               fc.Ratings,
+			Split:                 fc.Split,
+			Publish:               fc.Publish,
+		})
+	}
+	return feeds, nil
+}
+
+// parseDiscountUnits сопоставляет ID типов скидки промокодов единицам скидки
+func parseDiscountUnits(percentTypes, currencyTypes string) (map[int]entity.DiscountUnit, error) {
+	units := make(map[int]entity.DiscountUnit)
+	for unit, list := range map[entity.DiscountUnit]string{
+		entity.DiscountUnitPercent:  percentTypes,
+		entity.DiscountUnitCurrency: currencyTypes,
+	} {
+		for _, item := range splitList(list) {
+			id, err := strconv.Atoi(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid discount type ID %q", item)
+			}
+			if _, ok := units[id]; ok {
+				return nil, fmt.Errorf("discount type %d is listed as both percent and currency", id)
+			}
+			units[id] = unit
+		}
+	}
+	return units, nil
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseCommand отделяет имя команды от флагов
+func parseCommand(args []string) (string, []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return commandExport, args
+	}
+
+	switch args[0] {
+	case commandExport, commandCheckLinks, commandSuggestCategories:
+		return args[0], args[1:]
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q (available: %s, %s, %s)\n", args[0], commandExport, commandCheckLinks, commandSuggestCategories)
+		os.Exit(2)
+		return "", nil
+	}
+}
+
+func parseFlags(args []string) *config.Config {
 	cfg := &config.Config{}
 
 	// Сначала загружаем из .env
@@ -66,6 +450,12 @@ func parseFlags() *config.Config {
 	// Затем парсим флаги (они имеют приоритет над .env)
 	flag.StringVar(&cfg.GraphQLEndpoint, "endpoint", envCfg.GraphQLEndpoint, "GraphQL endpoint URL with token")
 	flag.StringVar(&cfg.OutputPath, "out", envCfg.OutputPath, "Output YML file path")
+	flag.StringVar(&cfg.FeedsConfigPath, "feeds", envCfg.FeedsConfigPath, "Feeds JSON config path (overrides --out)")
+	flag.StringVar(&cfg.FieldMappingPath, "mapping", envCfg.FieldMappingPath, "Field mapping JSON config path")
+	flag.StringVar(&cfg.CategoryMapPath, "category-map", envCfg.CategoryMapPath, "Category mapping JSON path (marketplace categories)")
+	flag.StringVar(&cfg.KitsPath, "kits", envCfg.KitsPath, "Product kits JSON file path")
+	flag.StringVar(&cfg.ReportPath, "report", envCfg.ReportPath, "Run report JSON path (empty = no report)")
+	flag.StringVar(&cfg.NotifyConfigPath, "notify", envCfg.NotifyConfigPath, "Notifications JSON config path (webhook, SMTP, Telegram)")
 	flag.StringVar(&cfg.ShopName, "shop-name", envCfg.ShopName, "Shop name")
 	flag.StringVar(&cfg.ShopCompany, "shop-company", envCfg.ShopCompany, "Company name")
 	flag.StringVar(&cfg.ShopURL, "shop-url", envCfg.ShopURL, "Shop URL")
@@ -73,8 +463,40 @@ func parseFlags() *config.Config {
 	flag.IntVar(&cfg.StatusID, "status-id", envCfg.StatusID, "Product status ID to filter (1 for new)")
 	flag.DurationVar(&cfg.HTTPTimeout, "timeout", envCfg.HTTPTimeout, "HTTP request timeout")
 	flag.StringVar(&cfg.LogLevel, "log-level", envCfg.LogLevel, "Log level (debug, info, warn, error)")
+	flag.StringVar(&cfg.ProductURLStrategy, "url-strategy", envCfg.ProductURLStrategy, "Product URL strategy (category, parent, template)")
+	flag.StringVar(&cfg.ProductURLTemplate, "url-template", envCfg.ProductURLTemplate, "Product URL template, e.g. {shop}/{category_path}/{slug}.html")
+	flag.BoolVar(&cfg.ProductURLTrailingSlash, "url-trailing-slash", envCfg.ProductURLTrailingSlash, "Append trailing slash to product URLs")
+	flag.StringVar(&cfg.ImageURLTemplate, "image-template", envCfg.ImageURLTemplate, "Image URL template, e.g. {cdn}/pics/items/{image}")
+	flag.StringVar(&cfg.ImageCDNURL, "image-cdn", envCfg.ImageCDNURL, "CDN base URL for images")
+	flag.BoolVar(&cfg.ImageIncludeMeta, "image-meta", envCfg.ImageIncludeMeta, "Include image title/description (alt text)")
+	flag.IntVar(&cfg.YMLMaxPictures, "yml-max-pictures", envCfg.YMLMaxPictures, "Maximum pictures per YML offer (0 = unlimited)")
+	flag.StringVar(&cfg.LinkCheckMode, "check-links", envCfg.LinkCheckMode, "Link check during export (off, flag, exclude)")
+	flag.StringVar(&cfg.LinkReportPath, "links-report", envCfg.LinkReportPath, "Link check JSON report path")
+	flag.IntVar(&cfg.LinkCheckConcurrency, "links-concurrency", envCfg.LinkCheckConcurrency, "Concurrent link checks")
+	flag.DurationVar(&cfg.LinkCheckTimeout, "links-timeout", envCfg.LinkCheckTimeout, "Timeout of a single link check")
+	flag.IntVar(&cfg.LinkCheckMinImageWidth, "links-min-width", envCfg.LinkCheckMinImageWidth, "Minimum image width in pixels (0 = skip)")
+	flag.IntVar(&cfg.LinkCheckMinImageHeight, "links-min-height", envCfg.LinkCheckMinImageHeight, "Minimum image height in pixels (0 = skip)")
+	flag.StringVar(&cfg.LinkCheckCachePath, "links-cache", envCfg.LinkCheckCachePath, "Link check cache file (empty = no cache)")
+	flag.DurationVar(&cfg.LinkCheckCacheTTL, "links-cache-ttl", envCfg.LinkCheckCacheTTL, "Link check cache TTL")
+	flag.StringVar(&cfg.ShopByClassification, "shopby-classification", envCfg.ShopByClassification, "Shop.by products to export (any, model, type, not)")
+	flag.StringVar(&cfg.ShopByAttributesPath, "shopby-attributes", envCfg.ShopByAttributesPath, "Shop.by required attributes JSON path")
+	flag.StringVar(&cfg.AvitoContactPhone, "avito-phone", envCfg.AvitoContactPhone, "Avito contact phone")
+	flag.StringVar(&cfg.AvitoAddress, "avito-address", envCfg.AvitoAddress, "Avito shop address")
+	flag.StringVar(&cfg.AvitoManagerName, "avito-manager", envCfg.AvitoManagerName, "Avito contact person")
+	flag.IntVar(&cfg.AvitoMaxImages, "avito-max-images", envCfg.AvitoMaxImages, "Maximum images per Avito ad (up to 10)")
+	flag.StringVar(&cfg.RozetkaRequiredParams, "rozetka-params", envCfg.RozetkaRequiredParams, "Comma-separated params required by Rozetka")
+	flag.StringVar(&cfg.KaspiMerchantID, "kaspi-merchant", envCfg.KaspiMerchantID, "Kaspi merchant ID")
+	flag.StringVar(&cfg.KaspiStoreIDs, "kaspi-stores", envCfg.KaspiStoreIDs, "Comma-separated Kaspi store IDs")
+	flag.StringVar(&cfg.TaxonomyPath, "taxonomy", envCfg.TaxonomyPath, "Marketplace taxonomy file for suggest-categories (default: bundled)")
+	flag.StringVar(&cfg.TaxonomyKey, "taxonomy-key", envCfg.TaxonomyKey, "Category map key to suggest (google_product_category, yandex_market_category)")
+	flag.StringVar(&cfg.SuggestPath, "suggest-out", envCfg.SuggestPath, "Category map with suggestions output path")
+	flag.Float64Var(&cfg.SuggestMinScore, "suggest-min-score", envCfg.SuggestMinScore, "Minimum name similarity (0..1) to accept a suggestion")
+	flag.StringVar(&cfg.DiscountPercentTypes, "discount-percent-types", envCfg.DiscountPercentTypes, "Comma-separated discount type IDs with percent discounts")
+	flag.StringVar(&cfg.DiscountCurrencyTypes, "discount-currency-types", envCfg.DiscountCurrencyTypes, "Comma-separated discount type IDs with fixed amount discounts")
+	flag.StringVar(&cfg.ReviewerIDSalt, "reviewer-salt", envCfg.ReviewerIDSalt, "Secret salt for review reviewer_id (empty = omit reviewer_id)")
+	flag.StringVar(&cfg.SitemapBaseURL, "sitemap-base-url", envCfg.SitemapBaseURL, "Public base URL of sitemap parts (default: shop URL)")
 
-	flag.Parse()
+	_ = flag.CommandLine.Parse(args)
 
 	// Валидация обязательных параметров
 	if cfg.GraphQLEndpoint == "" {
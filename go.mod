@@ -2,4 +2,13 @@ module beseller-yml-exporter
 
 go 1.25.3
 
-require github.com/joho/godotenv v1.5.1
+require (
+	github.com/joho/godotenv v1.5.1
+	github.com/pkg/sftp v1.13.10
+	golang.org/x/crypto v0.45.0
+)
+
+require (
+	github.com/kr/fs v0.1.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+)
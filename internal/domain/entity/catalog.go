@@ -0,0 +1,65 @@
+package entity
+
+// Shop содержит данные магазина для заголовка фида
+type Shop struct {
+	Name     string         // Название магазина
+	Company  string         // Название компании
+	URL      string         // URL магазина
+	Currency string         // Валюта фида
+	Delivery *DeliveryTerms // Условия доставки и самовывоза (nil — не выводятся)
+}
+
+// Catalog содержит данные, передаваемые writer'у для формирования одного фида
+type Catalog struct {
+	Shop       Shop
+	Categories []Category
+	Products   []Product
+	Promos     []Promo  // Акции по промокодам (выводятся форматами, которые их поддерживают)
+	Reviews    []Review // Принятые отзывы о товарах фида
+}
+
+// Part возвращает каталог с частью товаров для выгрузки фида несколькими файлами.
+// Акции и отзывы сокращаются до товаров части, чтобы каждый файл оставался
+// самостоятельным документом без ссылок на предложения из других частей.
+func (c Catalog) Part(products []Product) Catalog {
+	ids := make(map[string]bool, len(products))
+	for _, prod := range products {
+		ids[prod.ID] = true
+	}
+
+	part := c
+	part.Products = products
+	part.Promos = nil
+	for _, promo := range c.Promos {
+		offers := filterIDs(promo.OfferIDs, ids)
+		gifts := filterIDs(promo.GiftOfferIDs, ids)
+		if len(promo.OfferIDs) > 0 && len(offers) == 0 && len(promo.CategoryIDs) == 0 {
+			continue
+		}
+		if len(promo.GiftOfferIDs) > 0 && len(gifts) == 0 {
+			continue
+		}
+		promo.OfferIDs = offers
+		promo.GiftOfferIDs = gifts
+		part.Promos = append(part.Promos, promo)
+	}
+
+	part.Reviews = nil
+	for _, rev := range c.Reviews {
+		if ids[rev.ProductID] {
+			part.Reviews = append(part.Reviews, rev)
+		}
+	}
+	return part
+}
+
+// filterIDs оставляет только ID из множества ids
+func filterIDs(values []string, ids map[string]bool) []string {
+	var filtered []string
+	for _, id := range values {
+		if ids[id] {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
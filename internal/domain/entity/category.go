@@ -5,6 +5,11 @@ type Category struct {
 	ID       string  // Уникальный идентификатор категории
 	Name     string  // Название категории
 	ParentID *string // ID родительской категории (nil для корневых категорий)
+	Slug     string  // Сегмент URL страницы категории (page.url)
+	PageID   string  // ID страницы категории
+	URL      string  // Абсолютный URL страницы категории
+
+	Page PageMeta // Индексация и дата изменения страницы категории
 }
 
 // IsRoot проверяет, является ли категория корневой
@@ -0,0 +1,115 @@
+package entity
+
+import "strings"
+
+// CategoryTree представляет дерево категорий, проиндексированное по ID
+type CategoryTree struct {
+	byID     map[string]Category
+	children map[string][]string
+	order    []string
+}
+
+// NewCategoryTree строит индекс дерева категорий
+func NewCategoryTree(categories []Category) *CategoryTree {
+	t := &CategoryTree{
+		byID:     make(map[string]Category, len(categories)),
+		children: make(map[string][]string),
+		order:    make([]string, 0, len(categories)),
+	}
+	for _, cat := range categories {
+		if _, exists := t.byID[cat.ID]; exists {
+			continue
+		}
+		t.byID[cat.ID] = cat
+		t.order = append(t.order, cat.ID)
+		if !cat.IsRoot() {
+			t.children[*cat.ParentID] = append(t.children[*cat.ParentID], cat.ID)
+		}
+	}
+	return t
+}
+
+// Len возвращает количество категорий в дереве
+func (t *CategoryTree) Len() int {
+	return len(t.byID)
+}
+
+// Get возвращает категорию по ID
+func (t *CategoryTree) Get(id string) (Category, bool) {
+	cat, ok := t.byID[id]
+	return cat, ok
+}
+
+// Categories возвращает категории в исходном порядке
+func (t *CategoryTree) Categories() []Category {
+	result := make([]Category, 0, len(t.order))
+	for _, id := range t.order {
+		result = append(result, t.byID[id])
+	}
+	return result
+}
+
+// Children возвращает ID прямых потомков категории
+func (t *CategoryTree) Children(id string) []string {
+	return t.children[id]
+}
+
+// Path возвращает цепочку категорий от корня до указанной включительно.
+// Неизвестные родители обрывают цепочку, циклы игнорируются.
+func (t *CategoryTree) Path(id string) []Category {
+	var reversed []Category
+	visited := make(map[string]bool)
+
+	for current := id; current != "" && !visited[current]; {
+		cat, ok := t.byID[current]
+		if !ok {
+			break
+		}
+		visited[current] = true
+		reversed = append(reversed, cat)
+
+		if cat.IsRoot() {
+			break
+		}
+		current = *cat.ParentID
+	}
+
+	path := make([]Category, len(reversed))
+	for i, cat := range reversed {
+		path[len(reversed)-1-i] = cat
+	}
+	return path
+}
+
+// PathSlugs возвращает непустые сегменты URL от корня до указанной категории
+func (t *CategoryTree) PathSlugs(id string) []string {
+	path := t.Path(id)
+	segments := make([]string, 0, len(path))
+	for _, cat := range path {
+		if seg := strings.Trim(cat.Slug, "/"); seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	return segments
+}
+
+// PathNames возвращает названия категорий от корня до указанной
+func (t *CategoryTree) PathNames(id string) []string {
+	path := t.Path(id)
+	names := make([]string, 0, len(path))
+	for _, cat := range path {
+		names = append(names, cat.Name)
+	}
+	return names
+}
+
+// IsDescendant проверяет, входит ли категория id в поддерево ancestorID
+// (категория считается входящей в собственное поддерево)
+func (t *CategoryTree) IsDescendant(id, ancestorID string) bool {
+	for _, cat := range t.Path(id) {
+		if cat.ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
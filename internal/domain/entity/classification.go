@@ -0,0 +1,23 @@
+package entity
+
+// Classification представляет привязку товара к каталогу маркетплейса (тип и модель)
+type Classification struct {
+	TypeID  string // ID типа товара в каталоге маркетплейса
+	ModelID string // ID модели в каталоге маркетплейса
+}
+
+// HasModel проверяет, привязан ли товар к конкретной модели
+func (c *Classification) HasModel() bool {
+	return c != nil && c.ModelID != ""
+}
+
+// HasType проверяет, привязан ли товар к типу
+func (c *Classification) HasType() bool {
+	return c != nil && c.TypeID != ""
+}
+
+// TypeValue представляет значение атрибута типа маркетплейса из справочника (shopByTypeValues)
+type TypeValue struct {
+	AttributeID  string // ID атрибута типа
+	DictionaryID string // ID значения в справочнике атрибута
+}
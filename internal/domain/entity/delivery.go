@@ -0,0 +1,25 @@
+package entity
+
+// DeliveryType представляет способ доставки магазина (filterDeliveryType)
+type DeliveryType struct {
+	ID          string // ID способа доставки
+	Name        string // Название
+	Description string // Описание
+	Active      bool   // Включён ли способ доставки
+}
+
+// DeliveryOption представляет вариант доставки: стоимость, срок и время заказа
+type DeliveryOption struct {
+	Cost        Money  // Стоимость доставки
+	Days        string // Срок в днях: "0", "2" или "1-3"
+	OrderBefore *int   // Час, до которого нужно оформить заказ для указанного срока
+}
+
+// DeliveryTerms содержит условия доставки магазина
+type DeliveryTerms struct {
+	Delivery        bool             // Есть курьерская доставка
+	Pickup          bool             // Есть самовывоз
+	Store           bool             // Можно купить в розничном магазине
+	DeliveryOptions []DeliveryOption // Варианты курьерской доставки
+	PickupOptions   []DeliveryOption // Варианты самовывоза
+}
@@ -0,0 +1,16 @@
+package entity
+
+// ProductKit представляет комплект товаров BeSeller (ProductKit)
+type ProductKit struct {
+	ID          string   // ID комплекта
+	Name        string   // Название комплекта
+	Description string   // Описание комплекта
+	Position    int      // Порядок комплекта
+	ProductIDs  []string // Товары комплекта; первый — основной товар
+}
+
+// Param представляет характеристику предложения (<param>)
+type Param struct {
+	Name  string // Название характеристики
+	Value string // Значение
+}
@@ -0,0 +1,232 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount    = errors.New("invalid decimal amount")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrAmountOverflow   = errors.New("amount is out of range")
+)
+
+// DefaultMoneyScale - количество знаков после запятой для большинства валют
+const DefaultMoneyScale = 2
+
+// currencyScales задаёт точность валют, отличную от DefaultMoneyScale
+var currencyScales = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+}
+
+// CurrencyScale возвращает количество знаков после запятой для валюты
+func CurrencyScale(currency string) int {
+	if scale, ok := currencyScales[strings.ToUpper(currency)]; ok {
+		return scale
+	}
+	return DefaultMoneyScale
+}
+
+// RoundingMode определяет способ округления денежных сумм
+type RoundingMode int
+
+const (
+	RoundHalfUp RoundingMode = iota // Математическое округление (половина — от нуля)
+	RoundUp                         // Вверх (к +бесконечности)
+	RoundDown                       // Вниз (к -бесконечности)
+)
+
+// Money представляет точную денежную сумму с валютой и фиксированной точностью.
+// Сумма хранится в минимальных единицах: units * 10^-scale.
+type Money struct {
+	units    int64
+	scale    int
+	currency string
+}
+
+// NewMoney создаёт сумму из десятичной строки ("19.99"), округляя до точности валюты
+func NewMoney(amount, currency string) (Money, error) {
+	r, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return MoneyFromRat(r, currency, CurrencyScale(currency), RoundHalfUp)
+}
+
+// MoneyFromFloat создаёт сумму из float64 API, используя его кратчайшее десятичное представление.
+// NaN, бесконечность и суммы вне диапазона Money возвращают ошибку
+func MoneyFromFloat(amount float64, currency string) (Money, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+	m, err := MoneyFromRat(RatFromFloat(amount), currency, CurrencyScale(currency), RoundHalfUp)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %s", err, strconv.FormatFloat(amount, 'f', -1, 64))
+	}
+	return m, nil
+}
+
+// MoneyFromRat создаёт сумму из рационального числа с указанной точностью и округлением
+func MoneyFromRat(r *big.Rat, currency string, scale int, mode RoundingMode) (Money, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	units := RoundToInt(scaled, mode)
+	if !units.IsInt64() {
+		return Money{}, ErrAmountOverflow
+	}
+	return Money{units: units.Int64(), scale: scale, currency: currency}, nil
+}
+
+// ParseDecimal разбирает десятичную строку в точное рациональное число
+func ParseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(strings.ReplaceAll(s, ",", "."))
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "/eE") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	return r, nil
+}
+
+// RatFromFloat возвращает рациональное число по кратчайшему десятичному представлению float64
+func RatFromFloat(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if r == nil {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// Currency возвращает код валюты
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Scale возвращает количество знаков после запятой
+func (m Money) Scale() int {
+	return m.scale
+}
+
+// IsZero проверяет, равна ли сумма нулю
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// IsNegative проверяет, отрицательна ли сумма
+func (m Money) IsNegative() bool {
+	return m.units < 0
+}
+
+// Rat возвращает сумму как точное рациональное число
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.units), pow10(m.scale))
+}
+
+// Float64 возвращает приближённое значение суммы (только для статистики и логов)
+func (m Money) Float64() float64 {
+	f, _ := m.Rat().Float64()
+	return f
+}
+
+// String форматирует сумму с фиксированным числом знаков, например "1000000.00"
+func (m Money) String() string {
+	return m.Format(m.scale)
+}
+
+// Format форматирует сумму с указанным числом знаков после запятой (с округлением half-up)
+func (m Money) Format(decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	return m.Rat().FloatString(decimals)
+}
+
+// Cmp сравнивает суммы без учёта валюты: -1, 0 или 1
+func (m Money) Cmp(other Money) int {
+	return m.Rat().Cmp(other.Rat())
+}
+
+// Equal проверяет равенство сумм и валют
+func (m Money) Equal(other Money) bool {
+	return m.currency == other.currency && m.Cmp(other) == 0
+}
+
+// Add складывает суммы одной валюты
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	sum := new(big.Rat).Add(m.Rat(), other.Rat())
+	return MoneyFromRat(sum, m.currency, max(m.scale, other.scale), RoundHalfUp)
+}
+
+// Mul умножает сумму на точный коэффициент с округлением до точности суммы
+func (m Money) Mul(factor *big.Rat, mode RoundingMode) Money {
+	result, err := MoneyFromRat(new(big.Rat).Mul(m.Rat(), factor), m.currency, m.scale, mode)
+	if err != nil {
+		return m
+	}
+	return result
+}
+
+// WithRat возвращает сумму той же валюты и точности с новым значением
+func (m Money) WithRat(r *big.Rat, mode RoundingMode) Money {
+	result, err := MoneyFromRat(r, m.currency, m.scale, mode)
+	if err != nil {
+		return m
+	}
+	return result
+}
+
+// Convert переводит сумму в другую валюту по точному курсу
+// (rate — количество единиц целевой валюты за одну единицу исходной)
+func (m Money) Convert(currency string, rate *big.Rat) (Money, error) {
+	converted := new(big.Rat).Mul(m.Rat(), rate)
+	return MoneyFromRat(converted, currency, CurrencyScale(currency), RoundHalfUp)
+}
+
+// MarshalJSON сериализует сумму строкой с фиксированной точностью
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// pow10 возвращает 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// RoundToInt округляет рациональное число до целого
+func RoundToInt(r *big.Rat, mode RoundingMode) *big.Int {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	switch mode {
+	case RoundUp:
+		if r.Sign() > 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	case RoundDown:
+		if r.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		}
+	default:
+		// Сравниваем 2*|rem| с знаменателем
+		twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+		if twice.Cmp(den) >= 0 {
+			if r.Sign() > 0 {
+				quo.Add(quo, big.NewInt(1))
+			} else {
+				quo.Sub(quo, big.NewInt(1))
+			}
+		}
+	}
+	return quo
+}
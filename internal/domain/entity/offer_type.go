@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// OfferType определяет тип предложения YML
+type OfferType string
+
+const (
+	OfferTypeSimple      OfferType = ""             // Упрощённый тип: name
+	OfferTypeVendorModel OfferType = "vendor.model" // typePrefix + vendor + model
+)
+
+var (
+	ErrMissingVendor = errors.New("vendor.model offer requires vendor")
+	ErrMissingModel  = errors.New("vendor.model offer requires model")
+)
+
+// ApplyVendorModel переводит товар в тип vendor.model. Недостающие typePrefix и model
+// выводятся из названия: текст до производителя — typePrefix, после — model
+// ("Смартфон Apple iPhone 15" → "Смартфон", "iPhone 15"). Если typePrefix не
+// найден в названии, используется fallbackPrefix (например, название категории).
+func (p *Product) ApplyVendorModel(fallbackPrefix string) error {
+	if p.Vendor == nil || strings.TrimSpace(*p.Vendor) == "" {
+		return ErrMissingVendor
+	}
+	vendor := strings.TrimSpace(*p.Vendor)
+
+	prefix, model := splitByVendor(p.Name, vendor)
+	if p.Model == "" {
+		p.Model = model
+	}
+	if p.TypePrefix == "" {
+		p.TypePrefix = prefix
+	}
+	if p.TypePrefix == "" {
+		p.TypePrefix = strings.TrimSpace(fallbackPrefix)
+	}
+
+	if p.Model == "" {
+		return ErrMissingModel
+	}
+
+	p.OfferType = OfferTypeVendorModel
+	return nil
+}
+
+// splitByVendor делит название на часть до производителя и после него
+func splitByVendor(name, vendor string) (string, string) {
+	name = strings.TrimSpace(name)
+	start, end := indexFold(name, vendor)
+	if start < 0 {
+		return "", name
+	}
+	prefix := strings.TrimSpace(name[:start])
+	model := strings.TrimSpace(name[end:])
+	return strings.Trim(prefix, " ,-"), strings.Trim(model, " ,-")
+}
+
+// indexFold ищет substr в s без учёта регистра и возвращает границы совпадения
+// в байтах исходной строки. Сравнение идёт по рунам, поэтому смещения остаются
+// верными, даже если строчная и заглавная формы руны различаются по длине.
+func indexFold(s, substr string) (int, int) {
+	if substr == "" {
+		return -1, -1
+	}
+	for start := 0; start < len(s); {
+		if end, ok := hasPrefixFold(s[start:], substr); ok {
+			return start, start + end
+		}
+		_, size := utf8.DecodeRuneInString(s[start:])
+		start += size
+	}
+	return -1, -1
+}
+
+// hasPrefixFold проверяет, начинается ли s с prefix без учёта регистра, и
+// возвращает длину совпавшей части s в байтах
+func hasPrefixFold(s, prefix string) (int, bool) {
+	i := 0
+	for _, want := range prefix {
+		if i >= len(s) {
+			return 0, false
+		}
+		got, size := utf8.DecodeRuneInString(s[i:])
+		if !strings.EqualFold(string(got), string(want)) {
+			return 0, false
+		}
+		i += size
+	}
+	return i, true
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "testing"
+
+func TestSplitByVendor(t *testing.T) {
+	tests := []struct {
+		name, vendor  string
+		prefix, model string
+	}{
+		{"Смартфон Apple iPhone 15", "Apple", "Смартфон", "iPhone 15"},
+		{"смартфон APPLE iPhone 15", "apple", "смартфон", "iPhone 15"},
+		{"Apple iPhone 15", "Apple", "", "iPhone 15"},
+		{"iPhone 15", "Apple", "", "iPhone 15"},
+		{"İİİ Apple iPhone", "Apple", "İİİ", "iPhone"},
+		{"ȺȺȺȺ Apple iPhone", "apple", "ȺȺȺȺ", "iPhone"},
+		{"Чайник ȺȺȺ-Electro X1", "ⱥⱥⱥ-electro", "Чайник", "X1"},
+		{"Кофеварка БОШ, TKA 6A", "Бош", "Кофеварка", "TKA 6A"},
+		{"Ⱥ", "ⱥⱥ", "", "Ⱥ"},
+	}
+	for _, tt := range tests {
+		prefix, model := splitByVendor(tt.name, tt.vendor)
+		if prefix != tt.prefix || model != tt.model {
+			t.Errorf("splitByVendor(%q, %q) = %q, %q; want %q, %q",
+				tt.name, tt.vendor, prefix, model, tt.prefix, tt.model)
+		}
+	}
+}
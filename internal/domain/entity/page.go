@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+// PageMeta содержит сведения о странице сайта, нужные для карты сайта
+type PageMeta struct {
+	UpdatedAt *time.Time // Время последнего изменения страницы (updatedAt)
+	NoIndex   bool       // Страница закрыта от индексации (robotIndex=false) или неактивна
+}
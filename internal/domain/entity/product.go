@@ -13,11 +13,21 @@ var (
 	ErrInvalidProductPrice    = errors.New("product price must be positive")
 	ErrInvalidProductCategory = errors.New("product must belong to a category")
 	ErrInvalidCurrency        = errors.New("product currency is required")
+	ErrInvalidProductURL      = errors.New("product URL is required")
 )
 
 // Image представляет изображение товара
 type Image struct {
-	URL string // URL изображения
+	URL         string // URL изображения
+	Position    int    // Порядок изображения
+	Title       string // Заголовок (alt-текст), если включён в настройках
+	Description string // Описание изображения, если включено в настройках
+}
+
+// Tag представляет тег товара
+type Tag struct {
+	ID   string // ID тега
+	Name string // Название тега
 }
 
 // Product представляет товар
@@ -26,14 +36,41 @@ type Product struct {
 	Name        string  // Название товара
 	StatusID    int     // Статус товара (1 = новинка)
 	CategoryID  string  // ID категории
-	Price       float64 // Цена товара
-	Currency    string  // Валюта (BYN, USD, RUB и т.д.)
+	GroupID     string  // ID группы вариантов (модификаций); пусто — товар без вариантов
+	Price       Money   // Цена товара (точная сумма с валютой)
 	URL         string  // URL страницы товара
+	PageID      string  // ID страницы товара
 	Images      []Image // Изображения товара
 	Vendor      *string // Производитель/бренд
 	Barcode     *string // Штрих-код
+	SKU         *string // Артикул магазина
 	Description *string // Описание товара
 	Available   bool    // Доступен ли товар для заказа
+	Stock       *int    // Остаток на складе (nil — учёт остатков не ведётся)
+	Tags        []Tag   // Теги товара
+	Rating      *Rating // Сводный рейтинг по отзывам (nil — нет отзывов или не выводится)
+
+	DeliveryDays    *int             // Срок доставки товара в днях (deliveryDays)
+	OrderBefore     *int             // Час, до которого заказ уходит в указанный срок (orderBefore)
+	DeliveryOptions []DeliveryOption // Индивидуальные условия доставки товара в фиде
+
+	OfferType  OfferType // Тип предложения (пусто — упрощённый)
+	TypePrefix string    // Тип товара для vendor.model ("Смартфон")
+	Model      string    // Модель для vendor.model ("iPhone 15")
+
+	OnlinerClassification *Classification // Привязка к каталогу Onliner (onlinerClassification)
+	ShopByClassification  *Classification // Привязка к каталогу Shop.by (shopByClassification)
+	ShopByTypeValues      []TypeValue     // Значения атрибутов типа Shop.by (shopByTypeValues)
+
+	Page PageMeta // Индексация и дата изменения страницы товара
+
+	Attributes map[string]string // Дополнительные атрибуты из маппинга полей (model, warranty и т.д.)
+	Params     []Param           // Характеристики предложения (<param>)
+}
+
+// Attribute возвращает дополнительный атрибут товара
+func (p *Product) Attribute(name string) string {
+	return p.Attributes[name]
 }
 
 // IsNew проверяет, является ли товар новинкой
@@ -57,6 +94,23 @@ func (p *Product) GetImageURLs() []string {
 	return urls
 }
 
+// LimitImages возвращает не более limit изображений (limit <= 0 — без ограничения)
+func (p *Product) LimitImages(limit int) []Image {
+	if limit <= 0 || len(p.Images) <= limit {
+		return p.Images
+	}
+	return p.Images[:limit]
+}
+
+// GetImageURLsLimit возвращает не более limit URL изображений (limit <= 0 — без ограничения)
+func (p *Product) GetImageURLsLimit(limit int) []string {
+	urls := p.GetImageURLs()
+	if limit > 0 && len(urls) > limit {
+		return urls[:limit]
+	}
+	return urls
+}
+
 // Validate проверяет валидность товара
 func (p *Product) Validate() error {
 	if p.ID == "" {
@@ -65,14 +119,17 @@ func (p *Product) Validate() error {
 	if p.Name == "" {
 		return ErrInvalidProductName
 	}
-	if p.Price < 0 {
+	if p.Price.IsNegative() {
 		return ErrInvalidProductPrice
 	}
 	if p.CategoryID == "" {
 		return ErrInvalidProductCategory
 	}
-	if p.Currency == "" {
+	if p.Price.Currency() == "" {
 		return ErrInvalidCurrency
 	}
+	if p.URL == "" {
+		return ErrInvalidProductURL
+	}
 	return nil
 }
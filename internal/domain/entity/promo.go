@@ -0,0 +1,66 @@
+package entity
+
+import "time"
+
+// DiscountUnit определяет единицу скидки промокода
+type DiscountUnit string
+
+const (
+	DiscountUnitPercent  DiscountUnit = "percent"  // Скидка в процентах
+	DiscountUnitCurrency DiscountUnit = "currency" // Скидка в валюте магазина
+)
+
+// DiscountCode представляет промокод BeSeller (filterDiscountCodes)
+type DiscountCode struct {
+	ID          string       // ID промокода
+	Code        string       // Текст промокода
+	Name        string       // Название
+	Comment     string       // Комментарий
+	Active      bool         // Включён ли промокод
+	Unit        DiscountUnit // Единица скидки
+	Discount    float64      // Размер скидки
+	AllProducts bool         // Действует на весь каталог
+	PageIDs     []string     // Страницы товаров и категорий, на которые действует промокод
+	DateFrom    *time.Time   // Начало действия (включительно)
+	DateTo      *time.Time   // Окончание действия (включительно, до конца дня)
+}
+
+// IsActiveAt проверяет, действует ли промокод в указанный момент
+func (d *DiscountCode) IsActiveAt(t time.Time) bool {
+	if !d.Active || d.Code == "" || d.Discount <= 0 {
+		return false
+	}
+	if d.DateFrom != nil && t.Before(*d.DateFrom) {
+		return false
+	}
+	if d.DateTo != nil && t.After(d.DateTo.Add(24*time.Hour-time.Second)) {
+		return false
+	}
+	return true
+}
+
+// PromoType определяет тип акции фида
+type PromoType string
+
+const (
+	PromoTypePromoCode PromoType = "promo code"         // Скидка по промокоду
+	PromoTypeGift      PromoType = "gift with purchase" // Подарок при покупке
+)
+
+// Promo представляет акцию фида: промокод или подарок при покупке
+type Promo struct {
+	ID          string       // ID акции в фиде
+	Type        PromoType    // Тип акции
+	Code        string       // Промокод
+	Description string       // Описание акции
+	Unit        DiscountUnit // Единица скидки
+	Percent     float64      // Скидка в процентах (для DiscountUnitPercent)
+	Amount      Money        // Скидка в валюте фида (для DiscountUnitCurrency)
+	StartDate   *time.Time   // Начало действия
+	EndDate     *time.Time   // Окончание действия (конец дня)
+	OfferIDs    []string     // Товары, на которые действует акция
+	CategoryIDs []string     // Категории, на которые действует акция
+
+	RequiredQuantity int      // Сколько товаров нужно купить для получения подарка
+	GiftOfferIDs     []string // Предложения, выдаваемые в подарок
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Шкала оценки отзыва
+const (
+	MinReviewMark = 1
+	MaxReviewMark = 5
+)
+
+// Review представляет отзыв о товаре (filterReview)
+type Review struct {
+	ID        string    // ID отзыва
+	PageID    string    // Страница товара, к которой оставлен отзыв
+	ProductID string    // ID товара фида (заполняется при сопоставлении по PageID)
+	Name      string    // Имя автора
+	Email     string    // Email автора в замаскированном виде
+	AuthorID  string    // Обезличенный идентификатор автора (пусто, если не вычисляется)
+	Mark      int       // Оценка 1–5
+	Positive  string    // Достоинства
+	Negative  string    // Недостатки
+	Comment   string    // Текст отзыва
+	Accepted  bool      // Отзыв прошёл модерацию
+	CreatedAt time.Time // Дата создания
+}
+
+// HasValidMark проверяет, что оценка входит в шкалу 1–5
+func (r *Review) HasValidMark() bool {
+	return r.Mark >= MinReviewMark && r.Mark <= MaxReviewMark
+}
+
+// Rating представляет сводный рейтинг товара (reviewSummary)
+type Rating struct {
+	Value float64 // Средняя оценка
+	Count int     // Количество отзывов
+}
+
+// MaskEmail скрывает локальную часть адреса, оставляя первый символ: "ivan@mail.ru" → "i***@mail.ru"
+func MaskEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		if email == "" {
+			return ""
+		}
+		return "***"
+	}
+	local := []rune(email[:at])
+	return string(local[0]) + "***" + email[at:]
+}
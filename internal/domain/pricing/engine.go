@@ -0,0 +1,200 @@
+package pricing
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// Change описывает изменение цены товара одним правилом
+type Change struct {
+	ProductID string
+	Rule      string
+	From      entity.Money
+	To        entity.Money
+}
+
+// Engine применяет упорядоченные правила ценообразования к товарам
+type Engine struct {
+	rules []Rule
+	tree  *entity.CategoryTree
+}
+
+// NewEngine создаёт движок правил; tree используется для сопоставления поддеревьев категорий
+func NewEngine(rules []Rule, tree *entity.CategoryTree) (*Engine, error) {
+	prepared := make([]Rule, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		// Ступени наценки сортируются по priceFrom для выбора подходящей
+		tiers := make([]MarkupTier, len(rule.Markup))
+		copy(tiers, rule.Markup)
+		sort.SliceStable(tiers, func(a, b int) bool { return tiers[a].PriceFrom < tiers[b].PriceFrom })
+		rule.Markup = tiers
+		prepared[i] = rule
+	}
+	return &Engine{rules: prepared, tree: tree}, nil
+}
+
+// Apply изменяет цену товара и возвращает изменения, внесённые каждым правилом
+func (e *Engine) Apply(prod *entity.Product) []Change {
+	var changes []Change
+	base := prod.Price
+
+	for _, rule := range e.rules {
+		if !e.matches(rule.Match, prod, base) {
+			continue
+		}
+
+		before := prod.Price
+		prod.Price = applyRule(rule, prod.Price, base)
+		if !prod.Price.Equal(before) {
+			changes = append(changes, Change{
+				ProductID: prod.ID,
+				Rule:      rule.Name,
+				From:      before,
+				To:        prod.Price,
+			})
+		}
+
+		if rule.Stop {
+			break
+		}
+	}
+
+	return changes
+}
+
+// matches проверяет условия правила; диапазон цен сравнивается с исходной ценой
+func (e *Engine) matches(m Match, prod *entity.Product, base entity.Money) bool {
+	if m.PriceFrom != nil && base.Rat().Cmp(entity.RatFromFloat(*m.PriceFrom)) < 0 {
+		return false
+	}
+	if m.PriceTo != nil && base.Rat().Cmp(entity.RatFromFloat(*m.PriceTo)) >= 0 {
+		return false
+	}
+
+	if len(m.Categories) > 0 {
+		found := false
+		for _, catID := range m.Categories {
+			if prod.CategoryID == catID || (e.tree != nil && e.tree.IsDescendant(prod.CategoryID, catID)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(m.Vendors) > 0 {
+		if prod.Vendor == nil || !containsFold(m.Vendors, *prod.Vendor) {
+			return false
+		}
+	}
+
+	if len(m.Tags) > 0 {
+		found := false
+		for _, tag := range prod.Tags {
+			if containsFold(m.Tags, tag.ID) || containsFold(m.Tags, tag.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyRule последовательно применяет наценку, округление и минимальную цену
+func applyRule(rule Rule, price, base entity.Money) entity.Money {
+	if tier, ok := selectTier(rule.Markup, price); ok {
+		value := entity.RatFromFloat(tier.Value)
+		switch MarkupType(strings.ToLower(string(tier.Type))) {
+		case MarkupFixed:
+			price = price.WithRat(new(big.Rat).Add(price.Rat(), value), entity.RoundHalfUp)
+		case MarkupPercent:
+			factor := new(big.Rat).Add(big.NewRat(1, 1), new(big.Rat).Quo(value, big.NewRat(100, 1)))
+			price = price.Mul(factor, entity.RoundHalfUp)
+		}
+	}
+
+	if rule.Rounding != nil {
+		price = round(price, *rule.Rounding, rule.Rounding.Mode)
+	}
+
+	if rule.Floor != nil {
+		if minPrice := entity.RatFromFloat(rule.Floor.MinPrice); price.Rat().Cmp(minPrice) < 0 {
+			price = price.WithRat(minPrice, entity.RoundUp)
+		}
+		if rule.Floor.MinMarkupPercent > 0 {
+			percent := new(big.Rat).Quo(entity.RatFromFloat(rule.Floor.MinMarkupPercent), big.NewRat(100, 1))
+			minPrice := base.Mul(new(big.Rat).Add(big.NewRat(1, 1), percent), entity.RoundUp)
+			if price.Cmp(minPrice) < 0 {
+				price = minPrice
+				if rule.Rounding != nil {
+					// После поднятия до минимума сохраняем формат цены, не опускаясь ниже порога
+					price = round(price, *rule.Rounding, RoundUp)
+				}
+			}
+		}
+	}
+
+	return price
+}
+
+// selectTier выбирает ступень с наибольшим priceFrom, не превышающим цену
+func selectTier(tiers []MarkupTier, price entity.Money) (MarkupTier, bool) {
+	var selected MarkupTier
+	found := false
+	for _, tier := range tiers {
+		if price.Rat().Cmp(entity.RatFromFloat(tier.PriceFrom)) >= 0 {
+			selected = tier
+			found = true
+		}
+	}
+	return selected, found
+}
+
+// round приводит цену к виду N*Step + Ending
+func round(price entity.Money, r Rounding, mode RoundingMode) entity.Money {
+	step := entity.RatFromFloat(r.Step)
+	ending := entity.RatFromFloat(r.Ending)
+
+	units := new(big.Rat).Quo(new(big.Rat).Sub(price.Rat(), ending), step)
+	var n *big.Int
+	switch mode {
+	case RoundDown:
+		n = entity.RoundToInt(units, entity.RoundDown)
+	case RoundNearest:
+		n = entity.RoundToInt(units, entity.RoundHalfUp)
+	default:
+		n = entity.RoundToInt(units, entity.RoundUp)
+	}
+
+	result := new(big.Rat).Add(new(big.Rat).Mul(new(big.Rat).SetInt(n), step), ending)
+	if result.Sign() < 0 {
+		result = new(big.Rat)
+	}
+	return price.WithRat(result, entity.RoundHalfUp)
+}
+
+// containsFold проверяет наличие строки в списке без учёта регистра
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownMarkupType   = errors.New("unknown markup type")
+	ErrUnknownRoundingMode = errors.New("unknown rounding mode")
+	ErrInvalidStep         = errors.New("rounding step must be positive")
+	ErrEmptyRule           = errors.New("pricing rule has no actions")
+)
+
+// MarkupType определяет тип наценки (как B2bMarkup.type)
+type MarkupType string
+
+const (
+	MarkupPercent MarkupType = "percent" // Процент от цены
+	MarkupFixed   MarkupType = "fixed"   // Фиксированная сумма
+)
+
+// RoundingMode определяет направление округления
+type RoundingMode string
+
+const (
+	RoundUp      RoundingMode = "up"
+	RoundDown    RoundingMode = "down"
+	RoundNearest RoundingMode = "nearest"
+)
+
+// Match описывает условия применения правила; пустое условие совпадает со всеми товарами
+type Match struct {
+	Categories []string `json:"categories,omitempty"` // ID категорий, включая всё поддерево
+	Vendors    []string `json:"vendors,omitempty"`    // Производители (без учёта регистра)
+	Tags       []string `json:"tags,omitempty"`       // ID или названия тегов товара
+	PriceFrom  *float64 `json:"price_from,omitempty"` // Исходная цена от (включительно)
+	PriceTo    *float64 `json:"price_to,omitempty"`   // Исходная цена до (не включительно)
+}
+
+// MarkupTier представляет ступень наценки в стиле B2bMarkup (priceFrom, type, value)
+type MarkupTier struct {
+	PriceFrom float64    `json:"price_from"`
+	Type      MarkupType `json:"type"`
+	Value     float64    `json:"value"`
+}
+
+// Rounding описывает округление: цена приводится к виду N*Step + Ending.
+// Например, Step=1 и Ending=0.99 дают психологические цены x.99.
+type Rounding struct {
+	Mode   RoundingMode `json:"mode"`
+	Step   float64      `json:"step"`
+	Ending float64      `json:"ending,omitempty"`
+}
+
+// Floor описывает минимальную цену: абсолютную и/или минимальную наценку к исходной цене
+type Floor struct {
+	MinPrice         float64 `json:"min_price,omitempty"`
+	MinMarkupPercent float64 `json:"min_markup_percent,omitempty"`
+}
+
+// Rule представляет правило ценообразования; правила применяются по порядку
+type Rule struct {
+	Name     string       `json:"name"`
+	Match    Match        `json:"match"`
+	Markup   []MarkupTier `json:"markup,omitempty"`
+	Rounding *Rounding    `json:"rounding,omitempty"`
+	Floor    *Floor       `json:"floor,omitempty"`
+	Stop     bool         `json:"stop,omitempty"` // Не применять следующие правила
+}
+
+// Validate проверяет корректность правила
+func (r *Rule) Validate() error {
+	if len(r.Markup) == 0 && r.Rounding == nil && r.Floor == nil {
+		return fmt.Errorf("%w: %s", ErrEmptyRule, r.Name)
+	}
+	for _, tier := range r.Markup {
+		switch MarkupType(strings.ToLower(string(tier.Type))) {
+		case MarkupPercent, MarkupFixed:
+		default:
+			return fmt.Errorf("%w: %q in rule %s", ErrUnknownMarkupType, tier.Type, r.Name)
+		}
+	}
+	if r.Rounding != nil {
+		switch r.Rounding.Mode {
+		case RoundUp, RoundDown, RoundNearest:
+		default:
+			return fmt.Errorf("%w: %q in rule %s", ErrUnknownRoundingMode, r.Rounding.Mode, r.Name)
+		}
+		if r.Rounding.Step <= 0 {
+			return fmt.Errorf("%w: rule %s", ErrInvalidStep, r.Name)
+		}
+	}
+	return nil
+}
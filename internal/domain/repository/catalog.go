@@ -13,4 +13,13 @@ type CatalogRepository interface {
 	// GetProductsByStatus возвращает товары с указанным статусом
 	// statusID: 1 - новинка, 2 - хит продаж, и т.д.
 	GetProductsByStatus(ctx context.Context, statusID int) ([]entity.Product, error)
+
+	// GetDeliveryTypes возвращает способы доставки магазина
+	GetDeliveryTypes(ctx context.Context) ([]entity.DeliveryType, error)
+
+	// GetDiscountCodes возвращает включённые промокоды с условиями
+	GetDiscountCodes(ctx context.Context) ([]entity.DiscountCode, error)
+
+	// GetAcceptedReviews возвращает прошедшие модерацию отзывы о товарах
+	GetAcceptedReviews(ctx context.Context) ([]entity.Review, error)
 }
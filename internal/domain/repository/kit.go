@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// KitRepository определяет интерфейс получения комплектов товаров
+type KitRepository interface {
+	// GetProductKits возвращает комплекты товаров, упорядоченные по position
+	GetProductKits(ctx context.Context) ([]entity.ProductKit, error)
+}
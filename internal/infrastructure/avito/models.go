@@ -0,0 +1,42 @@
+package avito
+
+import "encoding/xml"
+
+// Ads представляет корневой элемент файла автозагрузки Avito
+type Ads struct {
+	XMLName       xml.Name `xml:"Ads"`
+	FormatVersion string   `xml:"formatVersion,attr"`
+	Target        string   `xml:"target,attr"`
+	Ad            []Ad     `xml:"Ad"`
+}
+
+// Ad представляет объявление
+type Ad struct {
+	ID           string  `xml:"Id"`
+	ManagerName  string  `xml:"ManagerName,omitempty"`
+	ContactPhone string  `xml:"ContactPhone,omitempty"`
+	Address      string  `xml:"Address"`
+	Category     string  `xml:"Category"`
+	GoodsType    string  `xml:"GoodsType,omitempty"`
+	AdType       string  `xml:"AdType"`
+	Condition    string  `xml:"Condition"`
+	Title        string  `xml:"Title"`
+	Description  CDATA   `xml:"Description"`
+	Price        string  `xml:"Price"`
+	Images       *Images `xml:"Images,omitempty"`
+}
+
+// CDATA представляет текст, записываемый в секции CDATA
+type CDATA struct {
+	Value string `xml:",cdata"`
+}
+
+// Images представляет фотографии объявления
+type Images struct {
+	Image []Image `xml:"Image"`
+}
+
+// Image представляет фотографию по URL
+type Image struct {
+	URL string `xml:"url,attr"`
+}
@@ -0,0 +1,144 @@
+package avito
+
+import (
+	"errors"
+	"fmt"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/categorymap"
+	"beseller-yml-exporter/internal/infrastructure/output"
+)
+
+// Ограничения автозагрузки Avito
+const (
+	MaxImages            = 10
+	maxTitleLength       = 50
+	maxDescriptionLength = 7500
+)
+
+// Значения объявлений магазина
+const (
+	adTypeReseller = "Товар приобретен на продажу"
+	conditionNew   = "Новое"
+)
+
+// Currency — Avito принимает цены только в рублях
+const Currency = "RUB"
+
+var ErrMissingAddress = errors.New("avito address is required")
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Options содержит настройки writer'а Avito
+type Options struct {
+	ContactPhone string             // Телефон для связи
+	Address      string             // Адрес магазина (обязателен)
+	ManagerName  string             // Контактное лицо
+	MaxImages    int                // Максимум фотографий (не больше 10)
+	Categories   *categorymap.Table // Соответствие категорий avito_category/avito_goods_type
+}
+
+// Writer реализует запись файла автозагрузки Avito
+type Writer struct {
+	logger Logger
+	opts   Options
+}
+
+// NewWriter создаёт новый writer Avito
+func NewWriter(logger Logger, opts Options) *Writer {
+	if opts.MaxImages <= 0 || opts.MaxImages > MaxImages {
+		opts.MaxImages = MaxImages
+	}
+	if opts.Categories == nil {
+		opts.Categories = categorymap.NewTable(nil)
+	}
+	return &Writer{logger: logger, opts: opts}
+}
+
+// Accept пропускает товары, категория которых сопоставлена категории Avito
+func (w *Writer) Accept(prod *entity.Product, tree *entity.CategoryTree) (bool, string) {
+	if _, ok := w.opts.Categories.Resolve(tree, prod.CategoryID, categorymap.KeyAvitoCategory); !ok {
+		return false, "avito category is not mapped"
+	}
+	return true, ""
+}
+
+// UnmappedCategories возвращает категории товаров без avito_category
+func (w *Writer) UnmappedCategories(tree *entity.CategoryTree, products []entity.Product) []string {
+	return w.opts.Categories.Unmapped(tree, products, categorymap.KeyAvitoCategory)
+}
+
+// Write записывает объявления в XML файл автозагрузки
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	if w.opts.Address == "" {
+		return ErrMissingAddress
+	}
+	if source.Shop.Currency != Currency {
+		return fmt.Errorf("avito feed requires %s prices, feed currency is %s", Currency, source.Shop.Currency)
+	}
+	for i := range source.Products {
+		if currency := source.Products[i].Price.Currency(); currency != Currency {
+			return fmt.Errorf("avito feed requires %s prices, product %s price is in %s", Currency, source.Products[i].ID, currency)
+		}
+	}
+
+	ads := w.buildAds(source)
+
+	return output.WriteXML(outputPath, "", ads)
+}
+
+// buildAds преобразует товары в объявления
+func (w *Writer) buildAds(source entity.Catalog) Ads {
+	tree := entity.NewCategoryTree(source.Categories)
+
+	ads := Ads{FormatVersion: "3", Target: "Avito.ru"}
+	ads.Ad = make([]Ad, 0, len(source.Products))
+	for i := range source.Products {
+		prod := &source.Products[i]
+
+		category, ok := w.opts.Categories.Resolve(tree, prod.CategoryID, categorymap.KeyAvitoCategory)
+		if !ok {
+			// Отбор выполняется в Accept; сюда товар без категории попадает только при прямом вызове
+			continue
+		}
+		goodsType, _ := w.opts.Categories.Resolve(tree, prod.CategoryID, categorymap.KeyAvitoGoodsType)
+
+		ad := Ad{
+			ID:           prod.ID,
+			ManagerName:  w.opts.ManagerName,
+			ContactPhone: w.opts.ContactPhone,
+			Address:      w.opts.Address,
+			Category:     category,
+			GoodsType:    goodsType,
+			AdType:       adTypeReseller,
+			Condition:    conditionNew,
+			Title:        output.Truncate(prod.Name, maxTitleLength),
+			Price:        prod.Price.Format(0),
+		}
+
+		description := prod.Name
+		if prod.Description != nil && *prod.Description != "" {
+			description = *prod.Description
+		}
+		ad.Description = CDATA{Value: output.Truncate(description, maxDescriptionLength)}
+
+		// Изображения по общим правилам с лимитом Avito
+		if images := prod.GetImageURLsLimit(w.opts.MaxImages); len(images) > 0 {
+			ad.Images = &Images{Image: make([]Image, 0, len(images))}
+			for _, url := range images {
+				ad.Images.Image = append(ad.Images.Image, Image{URL: url})
+			}
+		}
+
+		ads.Ad = append(ads.Ad, ad)
+	}
+
+	w.logger.Debug(fmt.Sprintf("Built %d Avito ads", len(ads.Ad)))
+	return ads
+}
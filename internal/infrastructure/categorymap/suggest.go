@@ -0,0 +1,128 @@
+package categorymap
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Вес похожести названия категории и полного пути в итоговой оценке
+const (
+	leafWeight = 0.75
+	pathWeight = 0.25
+)
+
+// DefaultMinScore — порог оценки, начиная с которого подсказка записывается в таблицу
+const DefaultMinScore = 0.5
+
+// Suggester подбирает категории площадки по названиям категорий магазина
+type Suggester struct {
+	table    *Table
+	key      string
+	minScore float64
+	entries  []indexedEntry
+}
+
+// indexedEntry — категория таксономии с заранее посчитанными триграммами
+type indexedEntry struct {
+	entry TaxonomyEntry
+	leaf  map[string]bool
+	path  map[string]bool
+}
+
+// NewSuggester создаёт подбор соответствий для ключа key по таксономии
+func NewSuggester(table *Table, taxonomy Taxonomy, key string, minScore float64) *Suggester {
+	if minScore <= 0 {
+		minScore = DefaultMinScore
+	}
+	entries := make([]indexedEntry, 0, len(taxonomy))
+	for _, entry := range taxonomy {
+		entries = append(entries, indexedEntry{
+			entry: entry,
+			leaf:  trigrams(entry.Path[len(entry.Path)-1]),
+			path:  trigrams(strings.Join(entry.Path, " ")),
+		})
+	}
+	return &Suggester{table: table, key: key, minScore: minScore, entries: entries}
+}
+
+// Suggest подбирает категорию площадки для каждой категории магазина,
+// у которой нет ни собственного, ни унаследованного значения ключа
+func (s *Suggester) Suggest(tree *entity.CategoryTree) []dto.CategorySuggestion {
+	var suggestions []dto.CategorySuggestion
+	for _, cat := range tree.Categories() {
+		if _, ok := s.table.Resolve(tree, cat.ID, s.key); ok {
+			continue
+		}
+
+		names := tree.PathNames(cat.ID)
+		leaf := trigrams(cat.Name)
+		path := trigrams(strings.Join(names, " "))
+
+		suggestion := dto.CategorySuggestion{
+			CategoryID: cat.ID,
+			Category:   strings.Join(names, " > "),
+			Key:        s.key,
+		}
+		for _, candidate := range s.entries {
+			score := leafWeight*dice(leaf, candidate.leaf) + pathWeight*dice(path, candidate.path)
+			if score > suggestion.Score {
+				suggestion.Score = score
+				suggestion.Value = candidate.entry.Value(s.key)
+				suggestion.Label = candidate.entry.Label()
+			}
+		}
+		suggestion.Score = math.Round(suggestion.Score*100) / 100
+		suggestion.Accepted = suggestion.Value != "" && suggestion.Score >= s.minScore
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions
+}
+
+// Save записывает таблицу соответствия с принятыми подсказками
+func (s *Suggester) Save(path string, suggestions []dto.CategorySuggestion) error {
+	for _, suggestion := range suggestions {
+		if suggestion.Accepted {
+			s.table.Set(suggestion.CategoryID, suggestion.Key, suggestion.Value)
+		}
+	}
+	return s.table.Save(path)
+}
+
+// trigrams возвращает множество символьных триграмм нормализованного текста;
+// слова дополняются пробелами, чтобы учитывались начала и окончания
+func trigrams(text string) map[string]bool {
+	result := make(map[string]bool)
+	for _, word := range normalize(text) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])] = true
+		}
+	}
+	return result
+}
+
+// normalize приводит текст к словам в нижнем регистре без пунктуации, ё заменяется на е
+func normalize(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// dice — коэффициент Сёренсена — Дайса для множеств триграмм
+func dice(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for gram := range a {
+		if b[gram] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
@@ -0,0 +1,129 @@
+package categorymap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// Ключи значений площадок в таблице соответствия категорий
+const (
+	KeyGoogleProductCategory = "google_product_category" // Категория Google (ID или путь таксономии)
+	KeyGoogleProductType     = "google_product_type"     // product_type Google вместо пути категорий магазина
+	KeyYandexMarketCategory  = "yandex_market_category"  // Подсказка категории Яндекс.Маркета (market_category)
+	KeyAvitoCategory         = "avito_category"          // Category объявления Avito
+	KeyAvitoGoodsType        = "avito_goods_type"        // GoodsType объявления Avito
+)
+
+// Mapping содержит значения площадок для одной категории BeSeller
+type Mapping map[string]string
+
+// tableFile представляет JSON файл: {"categories": {"<categoryId>": {"<key>": "<value>"}}}
+type tableFile struct {
+	Categories map[string]Mapping `json:"categories"`
+}
+
+// Table сопоставляет категории BeSeller с категориями площадок.
+// Значение наследуется подкатегориями, пока не переопределено ниже по дереву.
+type Table struct {
+	entries map[string]Mapping
+}
+
+// NewTable создаёт таблицу из готовых соответствий
+func NewTable(entries map[string]Mapping) *Table {
+	if entries == nil {
+		entries = map[string]Mapping{}
+	}
+	return &Table{entries: entries}
+}
+
+// Load загружает таблицу соответствия из JSON файла; пустой путь даёт пустую таблицу
+func Load(path string) (*Table, error) {
+	if path == "" {
+		return NewTable(nil), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read category map: %w", err)
+	}
+
+	var file tableFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse category map %s: %w", path, err)
+	}
+
+	for categoryID, mapping := range file.Categories {
+		for key, value := range mapping {
+			mapping[key] = strings.TrimSpace(value)
+		}
+		file.Categories[categoryID] = mapping
+	}
+	return NewTable(file.Categories), nil
+}
+
+// Has проверяет, задан ли ключ хотя бы для одной категории
+func (t *Table) Has(key string) bool {
+	for _, mapping := range t.entries {
+		if mapping[key] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Set задаёт значение ключа для категории
+func (t *Table) Set(categoryID, key, value string) {
+	if t.entries[categoryID] == nil {
+		t.entries[categoryID] = Mapping{}
+	}
+	t.entries[categoryID][key] = value
+}
+
+// Save записывает таблицу в JSON файл того же формата, что читает Load
+func (t *Table) Save(path string) error {
+	data, err := json.MarshalIndent(tableFile{Categories: t.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode category map: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write category map: %w", err)
+	}
+	return nil
+}
+
+// Unmapped возвращает категории товаров, для которых значение ключа не задано
+// ни для них, ни для их предков; порядок — по первому товару категории
+func (t *Table) Unmapped(tree *entity.CategoryTree, products []entity.Product, key string) []string {
+	seen := make(map[string]bool)
+	var unmapped []string
+	for i := range products {
+		categoryID := products[i].CategoryID
+		if seen[categoryID] {
+			continue
+		}
+		seen[categoryID] = true
+		if _, ok := t.Resolve(tree, categoryID, key); !ok {
+			unmapped = append(unmapped, categoryID)
+		}
+	}
+	return unmapped
+}
+
+// Resolve возвращает значение ключа для категории, поднимаясь к ближайшему
+// предку, для которого оно задано
+func (t *Table) Resolve(tree *entity.CategoryTree, categoryID, key string) (string, bool) {
+	path := tree.Path(categoryID)
+	if len(path) == 0 {
+		path = []entity.Category{{ID: categoryID}}
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if value := t.entries[path[i].ID][key]; value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
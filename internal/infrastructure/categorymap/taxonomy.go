@@ -0,0 +1,118 @@
+package categorymap
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//go:generate curl -fsSL -o taxonomy/google.ru-RU.txt https://www.google.com/basepages/producttype/taxonomy-with-ids.ru-RU.txt
+
+//go:embed taxonomy/*.txt
+var bundled embed.FS
+
+// Встроенные таксономии по ключам таблицы соответствия
+var bundledFiles = map[string]string{
+	KeyGoogleProductCategory: "taxonomy/google.ru-RU.txt",
+	KeyYandexMarketCategory:  "taxonomy/yandex.txt",
+}
+
+// TaxonomyEntry представляет категорию таксономии площадки
+type TaxonomyEntry struct {
+	ID   string   // ID категории ("267"); пусто, если таксономия без ID
+	Path []string // Путь от корня
+}
+
+// Label возвращает путь категории в виде строки
+func (e TaxonomyEntry) Label() string {
+	return strings.Join(e.Path, " > ")
+}
+
+// Value возвращает значение для таблицы соответствия: ID категории, если он есть,
+// иначе путь в формате ключа (для Яндекс.Маркета — через "/")
+func (e TaxonomyEntry) Value(key string) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	if key == KeyYandexMarketCategory {
+		return strings.Join(e.Path, "/")
+	}
+	return e.Label()
+}
+
+// Taxonomy — список категорий площадки
+type Taxonomy []TaxonomyEntry
+
+// LoadTaxonomy читает таксономию площадки из файла; пустой путь — встроенная таксономия ключа
+func LoadTaxonomy(path, key string) (Taxonomy, error) {
+	var data []byte
+	var err error
+	if path == "" {
+		name, ok := bundledFiles[key]
+		if !ok {
+			return nil, fmt.Errorf("no bundled taxonomy for key %q, specify a taxonomy file", key)
+		}
+		path = name
+		data, err = bundled.ReadFile(name)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read taxonomy: %w", err)
+	}
+
+	taxonomy := ParseTaxonomy(data)
+	if len(taxonomy) == 0 {
+		return nil, fmt.Errorf("taxonomy %s contains no categories", path)
+	}
+	return taxonomy, nil
+}
+
+// ParseTaxonomy разбирает строки вида "267 - Электроника > Связь > ...",
+// "Электроника > Связь" или "Все товары/Электроника"; строки с # — комментарии
+func ParseTaxonomy(data []byte) Taxonomy {
+	var taxonomy Taxonomy
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var entry TaxonomyEntry
+		if id, rest, ok := strings.Cut(line, " - "); ok && isDigits(id) {
+			entry.ID = id
+			line = rest
+		}
+
+		separator := " > "
+		if !strings.Contains(line, separator) && strings.Contains(line, "/") {
+			separator = "/"
+		}
+		for _, segment := range strings.Split(line, separator) {
+			if segment = strings.TrimSpace(segment); segment != "" {
+				entry.Path = append(entry.Path, segment)
+			}
+		}
+		if len(entry.Path) > 0 {
+			taxonomy = append(taxonomy, entry)
+		}
+	}
+	return taxonomy
+}
+
+// isDigits проверяет, что строка состоит только из цифр
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
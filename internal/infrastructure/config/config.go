@@ -10,15 +10,73 @@ import (
 
 // Config содержит конфигурацию приложения
 type Config struct {
-	GraphQLEndpoint string
-	ShopName        string
-	ShopCompany     string
-	ShopURL         string
-	Currency        string
-	StatusID        int
-	OutputPath      string
-	HTTPTimeout     time.Duration
-	LogLevel        string
+	GraphQLEndpoint  string
+	ShopName         string
+	ShopCompany      string
+	ShopURL          string
+	Currency         string
+	StatusID         int
+	OutputPath       string
+	FeedsConfigPath  string
+	ReportPath       string
+	FieldMappingPath string
+	KitsPath         string
+	CategoryMapPath  string
+	NotifyConfigPath string
+	HTTPTimeout      time.Duration
+	LogLevel         string
+
+	// Построение URL товаров
+	ProductURLStrategy      string // category, parent или template
+	ProductURLTemplate      string // например {shop}/{category_path}/{slug}.html
+	ProductURLTrailingSlash bool   // завершающий слеш в URL товара
+
+	// Изображения
+	ImageURLTemplate string // например {cdn}/pics/items/{image}
+	ImageCDNURL      string // базовый URL CDN для изображений
+	ImageIncludeMeta bool   // переносить imageTitle/imageDescription
+	YMLMaxPictures   int    // максимум <picture> в одном offer
+
+	// Проверка ссылок
+	LinkCheckMode           string        // off, flag или exclude
+	LinkReportPath          string        // JSON отчёт проверки ссылок
+	LinkCheckConcurrency    int           // количество параллельных проверок
+	LinkCheckTimeout        time.Duration // таймаут одной проверки
+	LinkCheckMinImageWidth  int           // минимальная ширина изображения
+	LinkCheckMinImageHeight int           // минимальная высота изображения
+	LinkCheckCachePath      string        // файл кэша результатов
+	LinkCheckCacheTTL       time.Duration // время жизни кэша
+
+	// Shop.by
+	ShopByClassification string // any, model, type или not (ShopByClassificationEnum)
+	ShopByAttributesPath string // JSON с обязательными атрибутами по типам Shop.by
+
+	// Avito
+	AvitoContactPhone string // телефон в объявлениях
+	AvitoAddress      string // адрес магазина
+	AvitoManagerName  string // контактное лицо
+	AvitoMaxImages    int    // максимум фотографий в объявлении (не больше 10)
+
+	// Диалекты YML
+	RozetkaRequiredParams string // обязательные <param> Rozetka через запятую
+	KaspiMerchantID       string // ID продавца Kaspi
+	KaspiStoreIDs         string // склады Kaspi через запятую (PP1,PP2)
+
+	// Промокоды
+	DiscountPercentTypes  string // ID типов скидки (discountType) в процентах через запятую
+	DiscountCurrencyTypes string // ID типов скидки в валюте магазина через запятую
+
+	// Отзывы
+	ReviewerIDSalt string // соль reviewer_id отзывов (пусто — reviewer_id не выводится)
+
+	// Карта сайта
+	SitemapBaseURL string // адрес, по которому публикуются части карты (пусто — URL магазина)
+
+	// Подбор соответствий категорий (команда suggest-categories)
+	TaxonomyPath    string  // файл таксономии площадки (пусто — встроенная)
+	TaxonomyKey     string  // ключ таблицы: google_product_category или yandex_market_category
+	SuggestPath     string  // таблица соответствия с подсказками
+	SuggestMinScore float64 // порог похожести названий для записи подсказки
 }
 
 // LoadFromEnv загружает конфигурацию из переменных окружения
@@ -27,15 +85,63 @@ func LoadFromEnv() *Config {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		GraphQLEndpoint: os.Getenv("GRAPHQL_ENDPOINT"),
-		ShopName:        getEnvOrDefault("SHOP_NAME", "Demo Shop"),
-		ShopCompany:     getEnvOrDefault("SHOP_COMPANY", "Company"),
-		ShopURL:         getEnvOrDefault("SHOP_URL", "https://demo.beseller.com"),
-		Currency:        getEnvOrDefault("CURRENCY", "BYN"),
-		StatusID:        getEnvAsInt("STATUS_ID", 1),
-		OutputPath:      getEnvOrDefault("OUTPUT_PATH", "export.yml"),
-		HTTPTimeout:     getEnvAsDuration("HTTP_TIMEOUT", 30*time.Second),
-		LogLevel:        getEnvOrDefault("LOG_LEVEL", "info"),
+		GraphQLEndpoint:  os.Getenv("GRAPHQL_ENDPOINT"),
+		ShopName:         getEnvOrDefault("SHOP_NAME", "Demo Shop"),
+		ShopCompany:      getEnvOrDefault("SHOP_COMPANY", "Company"),
+		ShopURL:          getEnvOrDefault("SHOP_URL", "https://demo.beseller.com"),
+		Currency:         getEnvOrDefault("CURRENCY", "BYN"),
+		StatusID:         getEnvAsInt("STATUS_ID", 1),
+		OutputPath:       getEnvOrDefault("OUTPUT_PATH", "export.yml"),
+		FeedsConfigPath:  os.Getenv("FEEDS_CONFIG"),
+		ReportPath:       getEnvOrDefault("REPORT_PATH", "export-report.json"),
+		FieldMappingPath: os.Getenv("FIELD_MAPPING"),
+		KitsPath:         os.Getenv("KITS_PATH"),
+		CategoryMapPath:  os.Getenv("CATEGORY_MAP"),
+		NotifyConfigPath: os.Getenv("NOTIFY_CONFIG"),
+		HTTPTimeout:      getEnvAsDuration("HTTP_TIMEOUT", 30*time.Second),
+		LogLevel:         getEnvOrDefault("LOG_LEVEL", "info"),
+
+		ProductURLStrategy:      getEnvOrDefault("PRODUCT_URL_STRATEGY", "category"),
+		ProductURLTemplate:      os.Getenv("PRODUCT_URL_TEMPLATE"),
+		ProductURLTrailingSlash: getEnvAsBool("PRODUCT_URL_TRAILING_SLASH", true),
+
+		ImageURLTemplate: getEnvOrDefault("IMAGE_URL_TEMPLATE", "{cdn}/pics/items/{image}"),
+		ImageCDNURL:      os.Getenv("IMAGE_CDN_URL"),
+		ImageIncludeMeta: getEnvAsBool("IMAGE_INCLUDE_META", false),
+		YMLMaxPictures:   getEnvAsInt("YML_MAX_PICTURES", 10),
+
+		LinkCheckMode:           getEnvOrDefault("LINK_CHECK_MODE", "off"),
+		LinkReportPath:          getEnvOrDefault("LINK_REPORT_PATH", "links-report.json"),
+		LinkCheckConcurrency:    getEnvAsInt("LINK_CHECK_CONCURRENCY", 8),
+		LinkCheckTimeout:        getEnvAsDuration("LINK_CHECK_TIMEOUT", 15*time.Second),
+		LinkCheckMinImageWidth:  getEnvAsInt("LINK_CHECK_MIN_IMAGE_WIDTH", 0),
+		LinkCheckMinImageHeight: getEnvAsInt("LINK_CHECK_MIN_IMAGE_HEIGHT", 0),
+		LinkCheckCachePath:      getEnvOrDefault("LINK_CHECK_CACHE", ".linkcheck-cache.json"),
+		LinkCheckCacheTTL:       getEnvAsDuration("LINK_CHECK_CACHE_TTL", 24*time.Hour),
+
+		ShopByClassification: getEnvOrDefault("SHOPBY_CLASSIFICATION", "model"),
+		ShopByAttributesPath: os.Getenv("SHOPBY_ATTRIBUTES"),
+
+		AvitoContactPhone: os.Getenv("AVITO_CONTACT_PHONE"),
+		AvitoAddress:      os.Getenv("AVITO_ADDRESS"),
+		AvitoManagerName:  os.Getenv("AVITO_MANAGER_NAME"),
+		AvitoMaxImages:    getEnvAsInt("AVITO_MAX_IMAGES", 10),
+
+		RozetkaRequiredParams: os.Getenv("ROZETKA_REQUIRED_PARAMS"),
+		KaspiMerchantID:       os.Getenv("KASPI_MERCHANT_ID"),
+		KaspiStoreIDs:         os.Getenv("KASPI_STORE_IDS"),
+
+		DiscountPercentTypes:  os.Getenv("DISCOUNT_PERCENT_TYPES"),
+		DiscountCurrencyTypes: os.Getenv("DISCOUNT_CURRENCY_TYPES"),
+
+		ReviewerIDSalt: os.Getenv("REVIEWER_ID_SALT"),
+
+		SitemapBaseURL: os.Getenv("SITEMAP_BASE_URL"),
+
+		TaxonomyPath:    os.Getenv("TAXONOMY_PATH"),
+		TaxonomyKey:     getEnvOrDefault("TAXONOMY_KEY", "google_product_category"),
+		SuggestPath:     getEnvOrDefault("CATEGORY_SUGGEST_OUT", "category-map.suggested.json"),
+		SuggestMinScore: getEnvAsFloat("CATEGORY_SUGGEST_MIN_SCORE", 0.5),
 	}
 
 	return cfg
@@ -59,6 +165,26 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsFloat возвращает значение переменной окружения как float64 или значение по умолчанию
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsBool возвращает значение переменной окружения как bool или значение по умолчанию
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvAsDuration возвращает значение переменной окружения как duration или значение по умолчанию
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
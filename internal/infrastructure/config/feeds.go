@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"beseller-yml-exporter/internal/domain/pricing"
+	"beseller-yml-exporter/internal/infrastructure/publish"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// FeedConfig содержит настройки одного выходного фида
+type FeedConfig struct {
+	Name     string            `json:"name"`     // Уникальное имя фида
+	Format   string            `json:"format"`   // Формат writer'а (yml, template, ...)
+	Template string            `json:"template"` // Путь к шаблону text/template для формата template
+	Output   string            `json:"output"`   // Путь к выходному файлу
+	Tracking map[string]string `json:"tracking"` // UTM/трекинг параметры URL товаров
+	Pricing  []pricing.Rule    `json:"pricing"`  // Правила ценообразования
+	Currency string            `json:"currency"` // Валюта фида
+	Rates    map[string]string `json:"rates"`    // Точные курсы строками, например {"BYN": "28.75"}
+
+	VendorModelCategories []string `json:"vendor_model_categories"` // Категории для offer type="vendor.model"
+
+	Delivery *dto.DeliveryConfig `json:"delivery"` // Условия доставки и самовывоза
+	Promos   bool                `json:"promos"`   // Акции по промокодам BeSeller
+	Kits     string              `json:"kits"`     // Комплекты: off, bundle или gift
+	Ratings  bool                `json:"ratings"`  // Сводный рейтинг товаров в предложениях
+	Split    *dto.SplitConfig    `json:"split"`    // Разбиение на части по числу предложений и размеру
+
+	Publish []dto.PublishTarget `json:"publish"` // Публикация файлов фида после успешной выгрузки
+}
+
+// FormatTemplate — формат фида по пользовательскому шаблону
+const FormatTemplate = "template"
+
+// FeedsFile представляет JSON файл с описанием фидов и публикаторов
+type FeedsFile struct {
+	Feeds      []FeedConfig              `json:"feeds"`
+	Publishers map[string]publish.Config `json:"publishers"` // Места публикации по имени
+}
+
+// LoadFeeds загружает описание фидов из JSON файла
+func LoadFeeds(path string) (*FeedsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feeds config: %w", err)
+	}
+
+	var file FeedsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse feeds config %s: %w", path, err)
+	}
+	if len(file.Feeds) == 0 {
+		return nil, fmt.Errorf("feeds config %s contains no feeds", path)
+	}
+
+	for i := range file.Feeds {
+		if file.Feeds[i].Format == "" {
+			file.Feeds[i].Format = "yml"
+			if file.Feeds[i].Template != "" {
+				file.Feeds[i].Format = FormatTemplate
+			}
+		}
+		if file.Feeds[i].Name == "" {
+			file.Feeds[i].Name = fmt.Sprintf("feed-%d", i+1)
+		}
+	}
+
+	return &file, nil
+}
@@ -0,0 +1,111 @@
+package feedtemplate
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/output"
+)
+
+// defaultPathSeparator — разделитель categoryPath по умолчанию
+const defaultPathSeparator = " > "
+
+// funcMap возвращает функции шаблона; функции категорий работают по дереву tree
+func funcMap(tree *entity.CategoryTree) template.FuncMap {
+	return template.FuncMap{
+		// Экранирование
+		"xml":   escapeXML,
+		"cdata": cdata,
+		"csv":   csvField,
+
+		// Значения
+		"str":      toString,
+		"default":  defaultValue,
+		"lower":    func(v interface{}) string { return strings.ToLower(toString(v)) },
+		"upper":    func(v interface{}) string { return strings.ToUpper(toString(v)) },
+		"trim":     func(v interface{}) string { return strings.TrimSpace(toString(v)) },
+		"truncate": truncate,
+		"join":     strings.Join,
+		"date":     func(layout string, t time.Time) string { return t.Format(layout) },
+
+		// Деньги: {{ money .Price }} или {{ .Price | moneyf 0 }}
+		"money":  func(m entity.Money) string { return m.String() },
+		"moneyf": func(decimals int, m entity.Money) string { return m.Format(decimals) },
+
+		// Категории
+		"categoryName": func(id string) string {
+			cat, _ := tree.Get(id)
+			return cat.Name
+		},
+		"categoryPath": func(id string, separator ...string) string {
+			sep := defaultPathSeparator
+			if len(separator) > 0 {
+				sep = separator[0]
+			}
+			return strings.Join(tree.PathNames(id), sep)
+		},
+		"categoryNames": tree.PathNames,
+	}
+}
+
+// toString приводит значение к строке; nil-указатель даёт пустую строку
+func toString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case *string:
+		if value == nil {
+			return ""
+		}
+		return *value
+	case *int:
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(*value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// defaultValue возвращает fallback для пустого значения: {{ .Vendor | default "Без бренда" }}
+func defaultValue(fallback string, v interface{}) string {
+	if s := toString(v); s != "" {
+		return s
+	}
+	return fallback
+}
+
+// escapeXML экранирует текст для элементов и атрибутов XML
+func escapeXML(v interface{}) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(toString(v)))
+	return buf.String()
+}
+
+// cdata оборачивает текст в CDATA, разбивая встречающиеся "]]>"
+func cdata(v interface{}) string {
+	s := strings.ReplaceAll(toString(v), "]]>", "]]]]><![CDATA[>")
+	return "<![CDATA[" + s + "]]>"
+}
+
+// csvField заключает поле CSV в кавычки, если в нём есть разделители, кавычки или переводы строк
+func csvField(v interface{}) string {
+	s := toString(v)
+	if !strings.ContainsAny(s, ",;\"\r\n\t") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// truncate обрезает строку до n символов (рун): {{ .Name | truncate 50 }}
+func truncate(n int, v interface{}) string {
+	return output.Truncate(toString(v), n)
+}
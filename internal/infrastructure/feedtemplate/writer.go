@@ -0,0 +1,91 @@
+package feedtemplate
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/output"
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Data — данные, доступные шаблону
+type Data struct {
+	Shop       entity.Shop
+	Categories []entity.Category
+	Products   []*entity.Product // указатели, чтобы в шаблоне были доступны методы товара
+	Promos     []entity.Promo
+	Reviews    []entity.Review
+	Generated  time.Time
+}
+
+// Writer формирует фид по пользовательскому шаблону text/template
+type Writer struct {
+	logger Logger
+	tmpl   *template.Template
+}
+
+// NewWriter загружает и разбирает шаблон; ошибки синтаксиса выявляются до выгрузки
+func NewWriter(logger Logger, path string) (*Writer, error) {
+	tmpl, err := template.New(filepath.Base(path)).
+		Option("missingkey=error").
+		Funcs(funcMap(entity.NewCategoryTree(nil))).
+		ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	return &Writer{logger: logger, tmpl: tmpl}, nil
+}
+
+// Write выполняет шаблон над каталогом и записывает результат в файл
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	// Помощники категорий привязываются к дереву текущего каталога
+	tmpl, err := w.tmpl.Clone()
+	if err != nil {
+		return fmt.Errorf("failed to clone template: %w", err)
+	}
+	tmpl.Funcs(funcMap(entity.NewCategoryTree(source.Categories)))
+
+	data := Data{
+		Shop:       source.Shop,
+		Categories: source.Categories,
+		Products:   make([]*entity.Product, 0, len(source.Products)),
+		Promos:     source.Promos,
+		Reviews:    source.Reviews,
+		Generated:  time.Now(),
+	}
+	for i := range source.Products {
+		data.Products = append(data.Products, &source.Products[i])
+	}
+
+	file, err := output.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	buf := bufio.NewWriter(file)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", tmpl.Name(), err)
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	w.logger.Debug(fmt.Sprintf("Rendered template %s with %d products", tmpl.Name(), len(data.Products)))
+	return nil
+}
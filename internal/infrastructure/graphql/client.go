@@ -7,9 +7,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"sync"
 	"time"
+
+	"beseller-yml-exporter/internal/usecase/dto"
 )
 
+// operationRe выделяет имя операции из текста запроса
+var operationRe = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
 // Logger интерфейс для логирования
 type Logger interface {
 	Debug(msg string, args ...interface{})
@@ -23,6 +30,9 @@ type Client struct {
 	endpoint   string
 	httpClient *http.Client
 	logger     Logger
+
+	mu    sync.Mutex
+	stats dto.APIStats // статистика запросов для отчёта о запуске
 }
 
 // NewClient создаёт новый GraphQL клиент
@@ -55,7 +65,13 @@ type GraphQLError struct {
 }
 
 // Query выполняет GraphQL запрос
-func (c *Client) Query(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+func (c *Client) Query(ctx context.Context, query string, variables map[string]interface{}, result interface{}) (err error) {
+	started := time.Now()
+	retries := 0
+	defer func() {
+		c.record(operationName(query), time.Since(started), retries, err)
+	}()
+
 	req := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -84,6 +100,7 @@ func (c *Client) Query(ctx context.Context, query string, variables map[string]i
 			break
 		}
 		if i < maxRetries-1 {
+			retries++
 			c.logger.Warn(fmt.Sprintf("Request failed, retrying... (attempt %d/%d)", i+1, maxRetries))
 			time.Sleep(time.Second * time.Duration(i+1))
 		}
@@ -114,3 +131,44 @@ func (c *Client) Query(ctx context.Context, query string, variables map[string]i
 
 	return nil
 }
+
+// Stats возвращает статистику запросов, выполненных клиентом
+func (c *Client) Stats() dto.APIStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Operations = make(map[string]dto.APIOperationStats, len(c.stats.Operations))
+	for name, op := range c.stats.Operations {
+		stats.Operations[name] = op
+	}
+	return stats
+}
+
+// record учитывает выполненный запрос в статистике
+func (c *Client) record(operation string, duration time.Duration, retries int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stats.Operations == nil {
+		c.stats.Operations = make(map[string]dto.APIOperationStats)
+	}
+	op := c.stats.Operations[operation]
+	for _, s := range []*dto.APIOperationStats{&c.stats.APIOperationStats, &op} {
+		s.Requests++
+		s.Retries += retries
+		s.DurationMs += duration.Milliseconds()
+		if err != nil {
+			s.Errors++
+		}
+	}
+	c.stats.Operations[operation] = op
+}
+
+// operationName возвращает имя операции запроса или "anonymous"
+func operationName(query string) string {
+	if m := operationRe.FindStringSubmatch(query); m != nil {
+		return m[1]
+	}
+	return "anonymous"
+}
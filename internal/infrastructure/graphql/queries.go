@@ -1,52 +1,41 @@
 package graphql
 
 const (
-	// QueryCategories - запрос для получения всех категорий
+	// QueryFilterCategories - запрос для получения всех категорий вместе со ссылкой на родителя.
+	// Дерево категорий загружается один раз и индексируется по ID.
 	QueryFilterCategories = `
 		query FilterCategory {
 			filterCategory {
 				id
 				name
+				pageId
+				page {
+					url
+					robotIndex
+					status
+					updatedAt
+				}
+				parentCategory {
+					id
+				}
 			}
 		}
 	`
 
-	// QueryProducts - запрос для получения всех товаров
+	// QueryProducts - запрос для получения всех товаров.
+	// Путь категории восстанавливается по дереву категорий, поэтому здесь нужен только её ID.
 	QueryFilterProduct = `
 		query FilterProduct {
 			filterProduct {
 				id
 				name
 				statusId
+				pageId
+				modificationParentId
+				modificationCount
 				category {
-                          id
-                          name
-                          page {
-                            url
-                           }
-                          parentCategory {
-                                      id
-                                      name
-                                      page {
-                                        url
-                                      }
-                                      parentCategory {
-                                           id
-                                           name
-                                           page {
-                                              url
-                                           }
-                                        parentCategory {
-                                            id
-                                            name
-                                            page {
-                                                url
-                                            }
-                                        }
-                                      }
-                          }
-                }
-                 
+					id
+				}
 				price
 				priceToShow {     # объект Price, содержащий currency
                              name
@@ -55,15 +44,151 @@ const (
                 }		
                 images {
 					image
+					position
+					imageTitle
+					imageDescription
 				}
                 page {
                        url           # slug товара
+                       robotIndex
+                       status
+                       updatedAt
                        links {
                            parentUrl         # путь каждой категории-родителя
+                           parentId
+                           position
                        }
                 } 
 				itemCode
-				vendorCode				
+				vendorCode
+				count
+				countable
+				reviewSummary {
+					mark
+					reviewCount
+				}
+				onlinerClassification {
+					typeId
+					modelId
+				}
+				shopByClassification {
+					typeId
+					modelId
+				}
+				shopByTypeValues {
+					attributeId
+					dictionaryId
+				}
+				deliveryDays
+				orderBefore
+				tags {
+					id
+					name
+				}
+				additionalInfo {
+					description
+					fullDescription
+					fullName
+				}
+				stringValues {
+					fieldId
+					stringValue
+				}
+				textValues {
+					fieldId
+					textValue
+				}
+				selectValues {
+					fieldId
+					selectValue
+				}
+				intValues {
+					fieldId
+					intValue
+				}
+				floatValues {
+					fieldId
+					floatValue
+				}
+			}
+		}
+	`
+
+	// QueryFilterProductIDs - запрос ID товаров, отобранных фильтром API
+	// (например, по классификации Shop.by)
+	QueryFilterProductIDs = `
+		query FilterProductIDs($filter: ProductFilter) {
+			filterProduct(filter: $filter) {
+				id
+			}
+		}
+	`
+
+	// QueryFilterDeliveryTypes - запрос способов доставки магазина
+	QueryFilterDeliveryTypes = `
+		query FilterDeliveryType {
+			filterDeliveryType {
+				id
+				name
+				description
+				status
+			}
+		}
+	`
+
+	// QueryFilterDiscountCodes - запрос активных промокодов со страницами, на которые они действуют
+	QueryFilterDiscountCodes = `
+		query FilterDiscountCodes($filter: DiscountCodesFilter) {
+			filterDiscountCodes(filter: $filter) {
+				id
+				code
+				name
+				comment
+				status
+				discount
+				discountType {
+					id
+					title
+				}
+				condition {
+					allProducts
+					dateFrom
+					dateTo
+					pageIds {
+						pageId
+						pageType
+						parentPageId
+					}
+				}
+			}
+		}
+	`
+
+	// QueryFilterReviews - запрос принятых отзывов о товарах
+	QueryFilterReviews = `
+		query FilterReview($filter: ReviewFilter) {
+			filterReview(filter: $filter) {
+				id
+				pageId
+				name
+				email
+				mark
+				positive
+				negative
+				comment
+				accepted
+				createdAt
+			}
+		}
+	`
+
+	// QueryFilterGroupFields - запрос пользовательских полей для маппинга по названию
+	QueryFilterGroupFields = `
+		query FilterGroupField {
+			filterGroupField {
+				id
+				name
+				title
 			}
 		}
 	`
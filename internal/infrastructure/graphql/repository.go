@@ -2,12 +2,20 @@ package graphql
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"beseller-yml-exporter/internal/domain/entity"
 	"beseller-yml-exporter/internal/domain/repository"
+	"beseller-yml-exporter/internal/infrastructure/mapping"
+	"beseller-yml-exporter/internal/infrastructure/urlbuilder"
+	"beseller-yml-exporter/internal/usecase/dto"
 )
 
 // PriceDTO представляет объект Price из GraphQL API
@@ -19,29 +27,178 @@ type PriceDTO struct {
 
 type PageInfoDTO struct {
 	URL string `json:"url"`
+	PageStateDTO
+}
+
+// PageStateDTO содержит индексацию, статус и дату изменения страницы
+type PageStateDTO struct {
+	RobotIndex *bool   `json:"robotIndex"`
+	Status     *int    `json:"status"`
+	UpdatedAt  *string `json:"updatedAt"`
 }
 
 type CategoryDTO struct {
 	ID             int          `json:"id"`
 	Name           string       `json:"name"`
+	PageID         *int         `json:"pageId"`
 	Page           PageInfoDTO  `json:"page"`
-	ParentCategory *CategoryDTO `json:"parentCategory"` // рекурсивная ссылка
+	ParentCategory *CategoryDTO `json:"parentCategory"` // заполняется только ID родителя
 }
 
 // ImageDTO представляет изображение из PageImage
 type ImageDTO struct {
-	Image string `json:"image"` // имя файла изображения
+	Image            string  `json:"image"`    // имя файла изображения
+	Position         int     `json:"position"` // порядок изображения
+	ImageTitle       *string `json:"imageTitle"`
+	ImageDescription *string `json:"imageDescription"`
 }
 
 // PageLinkDTO представляет ссылку на родительскую категорию
 type PageLinkDTO struct {
 	ParentURL string `json:"parentUrl"`
+	ParentID  *int   `json:"parentId"`
+	Position  int    `json:"position"`
 }
 
 // PageDTO представляет страницу товара с slug и родительскими ссылками
 type PageDTO struct {
 	URL   string        `json:"url"`
 	Links []PageLinkDTO `json:"links"`
+	PageStateDTO
+}
+
+// TagDTO представляет тег товара (ProductTag)
+type TagDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// AdditionalInfoDTO представляет ProductAdditionalInfo
+type AdditionalInfoDTO struct {
+	Description     *string `json:"description"`
+	FullDescription *string `json:"fullDescription"`
+	FullName        *string `json:"fullName"`
+}
+
+// FieldValueDTO представляет значение пользовательского поля любого типа
+type FieldValueDTO struct {
+	FieldID     int      `json:"fieldId"`
+	StringValue *string  `json:"stringValue"`
+	TextValue   *string  `json:"textValue"`
+	SelectValue *string  `json:"selectValue"`
+	IntValue    *int     `json:"intValue"`
+	FloatValue  *float64 `json:"floatValue"`
+}
+
+// value возвращает значение поля как строку
+func (v FieldValueDTO) value() string {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.TextValue != nil:
+		return *v.TextValue
+	case v.SelectValue != nil:
+		return *v.SelectValue
+	case v.IntValue != nil:
+		return strconv.Itoa(*v.IntValue)
+	case v.FloatValue != nil:
+		return strconv.FormatFloat(*v.FloatValue, 'f', -1, 64)
+	}
+	return ""
+}
+
+// DeliveryTypeDTO представляет способ доставки
+type DeliveryTypeDTO struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Status      bool    `json:"status"`
+}
+
+// DeliveryTypesResponse представляет ответ на запрос способов доставки
+type DeliveryTypesResponse struct {
+	FilterDeliveryType []DeliveryTypeDTO `json:"filterDeliveryType"`
+}
+
+// DiscountCodePageDTO представляет страницу, на которую действует промокод
+type DiscountCodePageDTO struct {
+	PageID       *int `json:"pageId"`
+	PageType     *int `json:"pageType"`
+	ParentPageID *int `json:"parentPageId"`
+}
+
+// DiscountCodeDTO представляет промокод вместе с условиями
+type DiscountCodeDTO struct {
+	ID           int     `json:"id"`
+	Code         *string `json:"code"`
+	Name         *string `json:"name"`
+	Comment      *string `json:"comment"`
+	Status       bool    `json:"status"`
+	Discount     float64 `json:"discount"`
+	DiscountType *struct {
+		ID    int     `json:"id"`
+		Title *string `json:"title"`
+	} `json:"discountType"`
+	Condition *struct {
+		AllProducts bool                  `json:"allProducts"`
+		DateFrom    *string               `json:"dateFrom"`
+		DateTo      *string               `json:"dateTo"`
+		PageIDs     []DiscountCodePageDTO `json:"pageIds"`
+	} `json:"condition"`
+}
+
+// DiscountCodesResponse представляет ответ на запрос промокодов
+type DiscountCodesResponse struct {
+	FilterDiscountCodes []DiscountCodeDTO `json:"filterDiscountCodes"`
+}
+
+// ClassificationDTO представляет привязку товара к каталогу маркетплейса
+type ClassificationDTO struct {
+	TypeID  *int `json:"typeId"`
+	ModelID *int `json:"modelId"`
+}
+
+// TypeValueDTO представляет значение атрибута типа Shop.by
+type TypeValueDTO struct {
+	AttributeID  int `json:"attributeId"`
+	DictionaryID int `json:"dictionaryId"`
+}
+
+// ReviewSummaryDTO представляет сводный рейтинг товара
+type ReviewSummaryDTO struct {
+	Mark        float64 `json:"mark"`
+	ReviewCount *int    `json:"reviewCount"`
+}
+
+// ReviewDTO представляет отзыв о товаре
+type ReviewDTO struct {
+	ID        int     `json:"id"`
+	PageID    *int    `json:"pageId"`
+	Name      *string `json:"name"`
+	Email     string  `json:"email"`
+	Mark      *int    `json:"mark"`
+	Positive  *string `json:"positive"`
+	Negative  *string `json:"negative"`
+	Comment   *string `json:"comment"`
+	Accepted  *int    `json:"accepted"` // 1 — отзыв принят модератором
+	CreatedAt *string `json:"createdAt"`
+}
+
+// ReviewsResponse представляет ответ на запрос отзывов
+type ReviewsResponse struct {
+	FilterReview []ReviewDTO `json:"filterReview"`
+}
+
+// GroupFieldDTO представляет описание пользовательского поля
+type GroupFieldDTO struct {
+	ID    int     `json:"id"`
+	Name  *string `json:"name"`
+	Title *string `json:"title"`
+}
+
+// GroupFieldsResponse представляет ответ на запрос пользовательских полей
+type GroupFieldsResponse struct {
+	FilterGroupField []GroupFieldDTO `json:"filterGroupField"`
 }
 
 // ProductDTO представляет товар из GraphQL API
@@ -49,6 +206,7 @@ type ProductDTO struct {
 	ID          int         `json:"id"`
 	Name        string      `json:"name"`
 	StatusID    int         `json:"statusId"`
+	PageID      *int        `json:"pageId"`
 	Category    CategoryDTO `json:"category"`
 	Price       float64     `json:"price"`
 	PriceToShow []PriceDTO  `json:"priceToShow"` // массив объектов Price
@@ -56,6 +214,27 @@ type ProductDTO struct {
 	VendorCode  *string     `json:"vendorCode"`
 	Images      []ImageDTO  `json:"images"`
 	Page        PageDTO     `json:"page"`
+	Tags        []TagDTO    `json:"tags"`
+
+	ModificationParentID *int `json:"modificationParentId"` // родительский товар модификации
+	ModificationCount    *int `json:"modificationCount"`    // количество модификаций у родителя
+
+	ReviewSummary         *ReviewSummaryDTO  `json:"reviewSummary"`
+	OnlinerClassification *ClassificationDTO `json:"onlinerClassification"`
+	ShopByClassification  *ClassificationDTO `json:"shopByClassification"`
+	ShopByTypeValues      []TypeValueDTO     `json:"shopByTypeValues"`
+
+	Count        *int  `json:"count"`     // остаток на складе
+	Countable    *bool `json:"countable"` // ведётся ли учёт остатков
+	DeliveryDays *int  `json:"deliveryDays"`
+	OrderBefore  *int  `json:"orderBefore"`
+
+	AdditionalInfo *AdditionalInfoDTO `json:"additionalInfo"`
+	StringValues   []FieldValueDTO    `json:"stringValues"`
+	TextValues     []FieldValueDTO    `json:"textValues"`
+	SelectValues   []FieldValueDTO    `json:"selectValues"`
+	IntValues      []FieldValueDTO    `json:"intValues"`
+	FloatValues    []FieldValueDTO    `json:"floatValues"`
 }
 
 type CategoriesResponse struct {
@@ -69,13 +248,158 @@ type ProductsResponse struct {
 
 // CatalogRepository реализует repository.CatalogRepository через GraphQL
 type CatalogRepository struct {
-	client  *Client
-	logger  Logger
-	shopURL string
+	client *Client
+	logger Logger
+	urls   *urlbuilder.ProductURLBuilder
+	images *urlbuilder.ImageURLBuilder
+	mapper *mapping.Mapper
+
+	discountUnits map[int]entity.DiscountUnit // единица скидки по ID типа скидки промокода
+	reviewerSalt  string                      // соль идентификатора автора отзыва (пусто — не вычисляется)
+
+	treeMu sync.Mutex
+	tree   *entity.CategoryTree // дерево категорий, загружается один раз
 }
 
-func NewCatalogRepository(c *Client, log Logger, shopURL string) repository.CatalogRepository {
-	return &CatalogRepository{client: c, logger: log, shopURL: shopURL}
+func NewCatalogRepository(
+	c *Client,
+	log Logger,
+	urls *urlbuilder.ProductURLBuilder,
+	images *urlbuilder.ImageURLBuilder,
+	mapper *mapping.Mapper,
+	discountUnits map[int]entity.DiscountUnit,
+	reviewerSalt string,
+) repository.CatalogRepository {
+	return &CatalogRepository{
+		client:        c,
+		logger:        log,
+		urls:          urls,
+		images:        images,
+		mapper:        mapper,
+		discountUnits: discountUnits,
+		reviewerSalt:  reviewerSalt,
+	}
+}
+
+// fieldNames загружает соответствие названий пользовательских полей их ID
+func (r *CatalogRepository) fieldNames(ctx context.Context) (map[string]string, error) {
+	var resp GroupFieldsResponse
+	if err := r.client.Query(ctx, QueryFilterGroupFields, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to query group fields: %w", err)
+	}
+
+	names := make(map[string]string, len(resp.FilterGroupField)*2)
+	for _, field := range resp.FilterGroupField {
+		id := strconv.Itoa(field.ID)
+		if field.Name != nil && *field.Name != "" {
+			names[strings.ToLower(*field.Name)] = id
+		}
+		if field.Title != nil && *field.Title != "" {
+			names[strings.ToLower(*field.Title)] = id
+		}
+	}
+	return names, nil
+}
+
+// toRecord собирает исходные поля товара для маппинга
+func toRecord(dto ProductDTO, names map[string]string) mapping.Record {
+	rec := mapping.Record{
+		Core: map[string]string{
+			"id":   strconv.Itoa(dto.ID),
+			"name": dto.Name,
+		},
+		Info:   map[string]string{},
+		Fields: map[string]string{},
+		Names:  names,
+	}
+	if dto.VendorCode != nil {
+		rec.Core["vendorCode"] = *dto.VendorCode
+	}
+	if dto.ItemCode != nil {
+		rec.Core["itemCode"] = *dto.ItemCode
+	}
+	if info := dto.AdditionalInfo; info != nil {
+		if info.Description != nil {
+			rec.Info["description"] = *info.Description
+		}
+		if info.FullDescription != nil {
+			rec.Info["fullDescription"] = *info.FullDescription
+		}
+		if info.FullName != nil {
+			rec.Info["fullName"] = *info.FullName
+		}
+	}
+
+	// Несколько значений одного поля (мультиселект) объединяются через запятую
+	for _, values := range [][]FieldValueDTO{dto.StringValues, dto.TextValues, dto.SelectValues, dto.IntValues, dto.FloatValues} {
+		for _, v := range values {
+			id := strconv.Itoa(v.FieldID)
+			value := v.value()
+			if value == "" {
+				continue
+			}
+			if existing := rec.Fields[id]; existing != "" {
+				value = existing + ", " + value
+			}
+			rec.Fields[id] = value
+		}
+	}
+
+	for _, tag := range dto.Tags {
+		rec.Tags = append(rec.Tags, tag.Name)
+	}
+	return rec
+}
+
+// pageDateLayout — формат updatedAt страницы по умолчанию (YYYY-MM-dd HH:mm:ss)
+const pageDateLayout = "2006-01-02 15:04:05"
+
+// toPageMeta преобразует состояние страницы: robotIndex=false или status=0 закрывают её от индексации
+func toPageMeta(dto PageStateDTO) entity.PageMeta {
+	meta := entity.PageMeta{
+		NoIndex: (dto.RobotIndex != nil && !*dto.RobotIndex) || (dto.Status != nil && *dto.Status <= 0),
+	}
+	if dto.UpdatedAt != nil {
+		if updatedAt, err := time.ParseInLocation(pageDateLayout, *dto.UpdatedAt, time.Local); err == nil {
+			meta.UpdatedAt = &updatedAt
+		}
+	}
+	return meta
+}
+
+// toImageSources преобразует PageImage в исходные данные для построителя изображений
+func toImageSources(images []ImageDTO) []urlbuilder.ImageSource {
+	sources := make([]urlbuilder.ImageSource, 0, len(images))
+	for _, img := range images {
+		src := urlbuilder.ImageSource{
+			Image:    img.Image,
+			Position: img.Position,
+		}
+		if img.ImageTitle != nil {
+			src.Title = *img.ImageTitle
+		}
+		if img.ImageDescription != nil {
+			src.Description = *img.ImageDescription
+		}
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+// toParentLinks преобразует page.links в ссылки для построителя URL
+func toParentLinks(links []PageLinkDTO) []urlbuilder.ParentLink {
+	result := make([]urlbuilder.ParentLink, 0, len(links))
+	for _, link := range links {
+		parentLink := urlbuilder.ParentLink{
+			URL:      link.ParentURL,
+			Position: link.Position,
+		}
+		if link.ParentID != nil {
+			parentLink.ParentID = strconv.Itoa(*link.ParentID)
+		}
+		result = append(result, parentLink)
+	}
+	return result
 }
 
 // getCurrencyFromPrice извлекает валюту из объекта Price
@@ -88,42 +412,75 @@ func (r *CatalogRepository) getCurrencyFromPrice(prices []PriceDTO) string {
 	return "BYN"
 }
 
-func buildCategoryPath(cat *CategoryDTO) []string {
-	if cat == nil {
-		return nil
+// toCategory преобразует DTO категории в доменную сущность
+func toCategory(dto CategoryDTO) entity.Category {
+	cat := entity.Category{
+		ID:   strconv.Itoa(dto.ID),
+		Name: dto.Name,
+		Slug: strings.Trim(dto.Page.URL, "/"),
+	}
+	if dto.PageID != nil {
+		cat.PageID = strconv.Itoa(*dto.PageID)
+	}
+	cat.Page = toPageMeta(dto.Page.PageStateDTO)
+	if dto.ParentCategory != nil && dto.ParentCategory.ID != 0 && dto.ParentCategory.ID != dto.ID {
+		parentID := strconv.Itoa(dto.ParentCategory.ID)
+		cat.ParentID = &parentID
 	}
+	return cat
+}
 
-	// Сначала получаем путь родителя (от корня к текущей)
-	segments := buildCategoryPath(cat.ParentCategory)
+// categoryTree возвращает дерево категорий, загружая его при первом обращении
+func (r *CatalogRepository) categoryTree(ctx context.Context) (*entity.CategoryTree, error) {
+	r.treeMu.Lock()
+	defer r.treeMu.Unlock()
 
-	// Добавляем текущую категорию
-	if seg := strings.Trim(cat.Page.URL, "/"); seg != "" {
-		segments = append(segments, seg)
+	if r.tree != nil {
+		return r.tree, nil
 	}
 
-	return segments
-}
-
-func (r *CatalogRepository) GetCategories(ctx context.Context) ([]entity.Category, error) {
 	var resp CategoriesResponse
-
 	if err := r.client.Query(ctx, QueryFilterCategories, nil, &resp); err != nil {
 		return nil, fmt.Errorf("failed to query categories: %w", err)
 	}
 
 	categories := make([]entity.Category, 0, len(resp.FilterCategory))
 	for _, dto := range resp.FilterCategory {
-		categories = append(categories, entity.Category{
-			ID:   strconv.Itoa(dto.ID),
-			Name: dto.Name,
-		})
+		categories = append(categories, toCategory(dto))
 	}
 
+	// URL категории строится по пути slug'ов, поэтому нужен предварительный индекс
+	index := entity.NewCategoryTree(categories)
+	for i := range categories {
+		categories[i].URL = r.urls.CategoryURL(index.PathSlugs(categories[i].ID))
+	}
+
+	r.tree = entity.NewCategoryTree(categories)
+	r.logger.Debug(fmt.Sprintf("Indexed %d categories", r.tree.Len()))
+	return r.tree, nil
+}
+
+func (r *CatalogRepository) APIStats() dto.APIStats {
+	return r.client.Stats()
+}
+
+func (r *CatalogRepository) GetCategories(ctx context.Context) ([]entity.Category, error) {
+	tree, err := r.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := tree.Categories()
 	r.logger.Debug(fmt.Sprintf("Fetched %d categories", len(categories)))
 	return categories, nil
 }
 
 func (r *CatalogRepository) GetProductsByStatus(ctx context.Context, statusID int) ([]entity.Product, error) {
+	tree, err := r.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var resp ProductsResponse
 	vars := map[string]interface{}{
 		"first":  100,
@@ -135,52 +492,86 @@ func (r *CatalogRepository) GetProductsByStatus(ctx context.Context, statusID in
 		return nil, fmt.Errorf("failed to query products: %w", err)
 	}
 
+	// Названия пользовательских полей нужны только если маппинг на них ссылается
+	var names map[string]string
+	if r.mapper.UsesFieldNames() {
+		if names, err = r.fieldNames(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	r.logger.Debug(fmt.Sprintf("Received %d products from API, filtering by statusId=%d", len(resp.FilterProduct), statusID))
 
 	products := make([]entity.Product, 0, len(resp.FilterProduct))
 	for _, dto := range resp.FilterProduct {
-		// Маппинг изображений с полным URL
-		imgs := make([]entity.Image, 0, len(dto.Images))
-		for _, img := range dto.Images {
-			if img.Image != "" {
-				// Формируем полный URL изображения
-				fullImageURL := fmt.Sprintf("%s/pics/items/%s",
-					strings.TrimRight(r.shopURL, "/"), img.Image)
-				imgs = append(imgs, entity.Image{URL: fullImageURL})
-			}
-		}
+		// Изображения: сортировка по position, удаление дублей, шаблон URL/CDN
+		imgs := r.images.Build(strconv.Itoa(dto.ID), toImageSources(dto.Images))
 
-		// Собираем путь из иерархии категорий
-		categorySegments := buildCategoryPath(&dto.Category)
-
-		// Добавляем slug товара
-		allSegments := append(categorySegments, strings.Trim(dto.Page.URL, "/"))
+		// Путь категории берётся из индекса дерева категорий (глубина не ограничена)
+		categoryID := strconv.Itoa(dto.Category.ID)
+		if _, ok := tree.Get(categoryID); !ok {
+			r.logger.Warn(fmt.Sprintf("Product %d references unknown category %s", dto.ID, categoryID))
+		}
 
-		// Формируем полный URL
-		path := "/" + strings.Join(allSegments, "/") + "/"
-		fullPageURL := strings.TrimRight(r.shopURL, "/") + path
+		// Формируем канонический URL товара согласно выбранной стратегии
+		fullPageURL, err := r.urls.Build(urlbuilder.ProductPage{
+			ProductID:    strconv.Itoa(dto.ID),
+			CategoryID:   categoryID,
+			Slug:         dto.Page.URL,
+			CategoryPath: tree.PathSlugs(categoryID),
+			Links:        toParentLinks(dto.Page.Links),
+		})
+		if err != nil {
+			// Товар без URL не пройдёт валидацию и попадёт в отчёт как invalid_product
+			r.logger.Warn(fmt.Sprintf("Failed to build URL for product %d, product will be skipped: %v", dto.ID, err))
+		}
 
 		// Извлекаем валюту из priceToShow
 		currency := r.getCurrencyFromPrice(dto.PriceToShow)
+		price, err := entity.MoneyFromFloat(dto.Price, currency) // валюта из priceToShow[0].name
+		if err != nil {
+			// Нулевая цена вместо непредставимой опубликовала бы бесплатное предложение
+			r.logger.Warn(fmt.Sprintf("Product %d has invalid price, product skipped: %v", dto.ID, err))
+			continue
+		}
 
 		prod := entity.Product{
 			ID:         strconv.Itoa(dto.ID),
 			Name:       dto.Name,
 			StatusID:   dto.StatusID,
-			CategoryID: strconv.Itoa(dto.Category.ID),
-			Price:      dto.Price,
-			Currency:   currency, // валюта из priceToShow[0].name
+			CategoryID: categoryID,
+			Price:      price,
 			URL:        fullPageURL,
 			Images:     imgs,
 		}
 
-		// Опциональные поля
-		if dto.VendorCode != nil {
-			prod.Vendor = dto.VendorCode
+		if dto.PageID != nil {
+			prod.PageID = strconv.Itoa(*dto.PageID)
+		}
+		prod.Page = toPageMeta(dto.Page.PageStateDTO)
+		prod.Available = isAvailable(dto)
+		prod.Stock = stock(dto)
+		prod.GroupID = groupID(dto)
+		prod.OnlinerClassification = toClassification(dto.OnlinerClassification)
+		prod.ShopByClassification = toClassification(dto.ShopByClassification)
+		for _, value := range dto.ShopByTypeValues {
+			prod.ShopByTypeValues = append(prod.ShopByTypeValues, entity.TypeValue{
+				AttributeID:  strconv.Itoa(value.AttributeID),
+				DictionaryID: strconv.Itoa(value.DictionaryID),
+			})
 		}
-		if dto.ItemCode != nil {
-			prod.Barcode = dto.ItemCode
+		if summary := dto.ReviewSummary; summary != nil && summary.ReviewCount != nil && *summary.ReviewCount > 0 {
+			prod.Rating = &entity.Rating{Value: summary.Mark, Count: *summary.ReviewCount}
 		}
+		prod.DeliveryDays = dto.DeliveryDays
+		prod.OrderBefore = dto.OrderBefore
+
+		for _, tag := range dto.Tags {
+			prod.Tags = append(prod.Tags, entity.Tag{ID: strconv.Itoa(tag.ID), Name: tag.Name})
+		}
+
+		// Производитель, штрих-код, артикул и прочие атрибуты — по настраиваемому маппингу полей
+		r.mapper.Apply(toRecord(dto, names), &prod)
 
 		products = append(products, prod)
 	}
@@ -188,3 +579,243 @@ func (r *CatalogRepository) GetProductsByStatus(ctx context.Context, statusID in
 	r.logger.Debug(fmt.Sprintf("Mapped %d products with currency from API", len(products)))
 	return products, nil
 }
+
+func (r *CatalogRepository) GetProductIDs(ctx context.Context, statusID int, filter dto.ProductFilter) ([]string, error) {
+	apiFilter := map[string]interface{}{"statusId": statusID}
+	if filter.ShopByClassification != "" {
+		apiFilter["shopByClassification"] = filter.ShopByClassification
+	}
+
+	var resp ProductsResponse
+	vars := map[string]interface{}{"filter": apiFilter}
+	if err := r.client.Query(ctx, QueryFilterProductIDs, vars, &resp); err != nil {
+		return nil, fmt.Errorf("failed to query product IDs: %w", err)
+	}
+
+	ids := make([]string, 0, len(resp.FilterProduct))
+	for _, prod := range resp.FilterProduct {
+		ids = append(ids, strconv.Itoa(prod.ID))
+	}
+
+	r.logger.Debug(fmt.Sprintf("Fetched %d product IDs by %s", len(ids), filter))
+	return ids, nil
+}
+
+func (r *CatalogRepository) GetDeliveryTypes(ctx context.Context) ([]entity.DeliveryType, error) {
+	var resp DeliveryTypesResponse
+	if err := r.client.Query(ctx, QueryFilterDeliveryTypes, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to query delivery types: %w", err)
+	}
+
+	types := make([]entity.DeliveryType, 0, len(resp.FilterDeliveryType))
+	for _, dto := range resp.FilterDeliveryType {
+		dt := entity.DeliveryType{
+			ID:     strconv.Itoa(dto.ID),
+			Name:   dto.Name,
+			Active: dto.Status,
+		}
+		if dto.Description != nil {
+			dt.Description = *dto.Description
+		}
+		types = append(types, dt)
+	}
+
+	r.logger.Debug(fmt.Sprintf("Fetched %d delivery types", len(types)))
+	return types, nil
+}
+
+func (r *CatalogRepository) GetDiscountCodes(ctx context.Context) ([]entity.DiscountCode, error) {
+	var resp DiscountCodesResponse
+	vars := map[string]interface{}{
+		"filter": map[string]interface{}{"status": true},
+	}
+	if err := r.client.Query(ctx, QueryFilterDiscountCodes, vars, &resp); err != nil {
+		return nil, fmt.Errorf("failed to query discount codes: %w", err)
+	}
+
+	codes := make([]entity.DiscountCode, 0, len(resp.FilterDiscountCodes))
+	for _, dto := range resp.FilterDiscountCodes {
+		// Единица скидки задаётся настройками по ID типа: название типа не надёжно
+		if dto.DiscountType == nil {
+			r.logger.Warn(fmt.Sprintf("Discount code %d: discount type is missing, code skipped", dto.ID))
+			continue
+		}
+		unit, ok := r.discountUnits[dto.DiscountType.ID]
+		if !ok {
+			title := ""
+			if dto.DiscountType.Title != nil {
+				title = *dto.DiscountType.Title
+			}
+			r.logger.Warn(fmt.Sprintf("Discount code %d: unknown discount type %d (%q), code skipped; set DISCOUNT_PERCENT_TYPES or DISCOUNT_CURRENCY_TYPES", dto.ID, dto.DiscountType.ID, title))
+			continue
+		}
+
+		code := entity.DiscountCode{
+			ID:       strconv.Itoa(dto.ID),
+			Active:   dto.Status,
+			Discount: dto.Discount,
+			Unit:     unit,
+		}
+		if dto.Code != nil {
+			code.Code = strings.TrimSpace(*dto.Code)
+		}
+		if dto.Name != nil {
+			code.Name = *dto.Name
+		}
+		if dto.Comment != nil {
+			code.Comment = *dto.Comment
+		}
+
+		if cond := dto.Condition; cond != nil {
+			var err error
+			code.AllProducts = cond.AllProducts
+			if code.DateFrom, err = parseDiscountDate(cond.DateFrom); err != nil {
+				r.logger.Warn(fmt.Sprintf("Discount code %d: invalid dateFrom: %v", dto.ID, err))
+				continue
+			}
+			if code.DateTo, err = parseDiscountDate(cond.DateTo); err != nil {
+				r.logger.Warn(fmt.Sprintf("Discount code %d: invalid dateTo: %v", dto.ID, err))
+				continue
+			}
+			for _, page := range cond.PageIDs {
+				if page.PageID != nil {
+					code.PageIDs = append(code.PageIDs, strconv.Itoa(*page.PageID))
+				}
+			}
+		}
+
+		codes = append(codes, code)
+	}
+
+	r.logger.Debug(fmt.Sprintf("Fetched %d discount codes", len(codes)))
+	return codes, nil
+}
+
+func (r *CatalogRepository) GetAcceptedReviews(ctx context.Context) ([]entity.Review, error) {
+	var resp ReviewsResponse
+	vars := map[string]interface{}{
+		"filter": map[string]interface{}{"accepted": 1},
+	}
+	if err := r.client.Query(ctx, QueryFilterReviews, vars, &resp); err != nil {
+		return nil, fmt.Errorf("failed to query reviews: %w", err)
+	}
+
+	reviews := make([]entity.Review, 0, len(resp.FilterReview))
+	for _, dto := range resp.FilterReview {
+		// Дополнительная проверка модерации (на случай если API проигнорировал фильтр)
+		if dto.Accepted == nil || *dto.Accepted != 1 || dto.PageID == nil {
+			continue
+		}
+
+		review := entity.Review{
+			ID:       strconv.Itoa(dto.ID),
+			PageID:   strconv.Itoa(*dto.PageID),
+			Email:    entity.MaskEmail(dto.Email), // исходный адрес не покидает репозиторий
+			AuthorID: reviewerID(r.reviewerSalt, dto.Email),
+			Accepted: true,
+		}
+		if dto.Name != nil {
+			review.Name = strings.TrimSpace(*dto.Name)
+		}
+		if dto.Mark != nil {
+			review.Mark = *dto.Mark
+		}
+		if dto.Positive != nil {
+			review.Positive = strings.TrimSpace(*dto.Positive)
+		}
+		if dto.Negative != nil {
+			review.Negative = strings.TrimSpace(*dto.Negative)
+		}
+		if dto.Comment != nil {
+			review.Comment = strings.TrimSpace(*dto.Comment)
+		}
+		if dto.CreatedAt != nil && *dto.CreatedAt != "" {
+			createdAt, err := time.ParseInLocation("2006-01-02 15:04:05", *dto.CreatedAt, time.Local)
+			if err != nil {
+				r.logger.Warn(fmt.Sprintf("Review %d: invalid createdAt: %v", dto.ID, err))
+				continue
+			}
+			review.CreatedAt = createdAt
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	r.logger.Debug(fmt.Sprintf("Fetched %d accepted reviews", len(reviews)))
+	return reviews, nil
+}
+
+// toClassification преобразует классификацию маркетплейса; нулевые ID означают отсутствие привязки
+func toClassification(dto *ClassificationDTO) *entity.Classification {
+	if dto == nil {
+		return nil
+	}
+	var c entity.Classification
+	if dto.TypeID != nil && *dto.TypeID != 0 {
+		c.TypeID = strconv.Itoa(*dto.TypeID)
+	}
+	if dto.ModelID != nil && *dto.ModelID != 0 {
+		c.ModelID = strconv.Itoa(*dto.ModelID)
+	}
+	if c.TypeID == "" && c.ModelID == "" {
+		return nil
+	}
+	return &c
+}
+
+// groupID возвращает ID группы вариантов: родителя для модификации или сам товар,
+// если у него есть модификации
+func groupID(dto ProductDTO) string {
+	if dto.ModificationParentID != nil && *dto.ModificationParentID != 0 && *dto.ModificationParentID != dto.ID {
+		return strconv.Itoa(*dto.ModificationParentID)
+	}
+	if dto.ModificationCount != nil && *dto.ModificationCount > 0 {
+		return strconv.Itoa(dto.ID)
+	}
+	return ""
+}
+
+// isAvailable определяет наличие: без учёта остатков товар доступен всегда,
+// иначе — при положительном остатке
+func isAvailable(dto ProductDTO) bool {
+	if dto.Countable == nil || !*dto.Countable {
+		return true
+	}
+	return dto.Count != nil && *dto.Count > 0
+}
+
+// stock возвращает остаток товара с учётом остатков; отрицательный остаток считается нулевым
+func stock(dto ProductDTO) *int {
+	if dto.Countable == nil || !*dto.Countable || dto.Count == nil {
+		return nil
+	}
+	count := *dto.Count
+	if count < 0 {
+		count = 0
+	}
+	return &count
+}
+
+// reviewerID возвращает HMAC-SHA256 адреса автора с солью: один автор получает один ID,
+// а по ID нельзя восстановить ни адрес, ни его домен
+func reviewerID(salt, email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if salt == "" || email == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(email))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// parseDiscountDate разбирает дату условия промокода в формате YYYY-MM-dd
+func parseDiscountDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", *value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,72 @@
+package kits
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// kitDTO повторяет тип ProductKit из схемы BeSeller.
+// Схема описывает ProductKit, но не содержит запроса для его чтения,
+// поэтому комплекты выгружаются из админки в JSON файл той же структуры.
+type kitDTO struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Position    int    `json:"position"`
+	ProductIDs  []int  `json:"product_ids"`
+}
+
+// kitsFile представляет JSON файл с комплектами
+type kitsFile struct {
+	Kits []kitDTO `json:"kits"`
+}
+
+// FileRepository загружает комплекты товаров из JSON файла
+type FileRepository struct {
+	path string
+}
+
+// NewFileRepository создаёт репозиторий комплектов из файла
+func NewFileRepository(path string) *FileRepository {
+	return &FileRepository{path: path}
+}
+
+func (r *FileRepository) GetProductKits(ctx context.Context) ([]entity.ProductKit, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kits file: %w", err)
+	}
+
+	var file kitsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse kits file %s: %w", r.path, err)
+	}
+
+	kits := make([]entity.ProductKit, 0, len(file.Kits))
+	for _, dto := range file.Kits {
+		if dto.ID == 0 {
+			return nil, fmt.Errorf("kits file %s: kit without id", r.path)
+		}
+		kit := entity.ProductKit{
+			ID:          strconv.Itoa(dto.ID),
+			Name:        dto.Name,
+			Description: dto.Description,
+			Position:    dto.Position,
+		}
+		for _, productID := range dto.ProductIDs {
+			kit.ProductIDs = append(kit.ProductIDs, strconv.Itoa(productID))
+		}
+		kits = append(kits, kit)
+	}
+
+	sort.SliceStable(kits, func(i, j int) bool {
+		return kits[i].Position < kits[j].Position
+	})
+	return kits, nil
+}
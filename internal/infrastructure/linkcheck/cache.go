@@ -0,0 +1,92 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// cache хранит результаты проверок между запусками в JSON файле.
+// Записи хранятся по ключу проверки (см. Checker.cacheKey), а не только по URL
+type cache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	entries map[string]dto.LinkResult
+}
+
+// loadCache загружает кэш из файла (отсутствующий файл — пустой кэш)
+func loadCache(path string, ttl time.Duration) (*cache, error) {
+	c := &cache{
+		path:    path,
+		ttl:     ttl,
+		entries: make(map[string]dto.LinkResult),
+	}
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read link cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse link cache: %w", err)
+	}
+	return c, nil
+}
+
+// get возвращает успешный результат из кэша, если он не устарел.
+// Недоступные ссылки перепроверяются при каждом запуске.
+func (c *cache) get(key string, now time.Time) (dto.LinkResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, ok := c.entries[key]
+	if !ok || !res.OK || c.ttl <= 0 || now.Sub(res.CheckedAt) > c.ttl {
+		return dto.LinkResult{}, false
+	}
+	return res, true
+}
+
+// put сохраняет результат проверки
+func (c *cache) put(key string, res dto.LinkResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res.Cached = false
+	c.entries[key] = res
+}
+
+// save записывает кэш на диск, отбрасывая устаревшие записи
+func (c *cache) save(now time.Time) error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, res := range c.entries {
+		if now.Sub(res.CheckedAt) > c.ttl {
+			delete(c.entries, key)
+		}
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode link cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write link cache: %w", err)
+	}
+	return nil
+}
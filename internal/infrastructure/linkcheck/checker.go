@@ -0,0 +1,213 @@
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // регистрация декодера GIF для image.DecodeConfig
+	_ "image/jpeg" // регистрация декодера JPEG
+	_ "image/png"  // регистрация декодера PNG
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Config содержит настройки проверки ссылок
+type Config struct {
+	Concurrency    int           // Количество параллельных проверок
+	Timeout        time.Duration // Таймаут одного запроса
+	MinImageWidth  int           // Минимальная ширина изображения (0 — не проверять)
+	MinImageHeight int           // Минимальная высота изображения (0 — не проверять)
+	CachePath      string        // Файл кэша результатов между запусками
+	CacheTTL       time.Duration // Время жизни записи кэша
+	UserAgent      string        // User-Agent запросов
+}
+
+// Checker проверяет доступность страниц и изображений с ограниченным параллелизмом
+type Checker struct {
+	cfg        Config
+	httpClient *http.Client
+	logger     Logger
+}
+
+// NewChecker создаёт новый Checker
+func NewChecker(cfg Config, logger Logger) *Checker {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 8
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = "beseller-yml-exporter/linkcheck"
+	}
+	return &Checker{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		logger:     logger,
+	}
+}
+
+// Check проверяет ссылки; одинаковые URL проверяются один раз
+func (c *Checker) Check(ctx context.Context, targets []dto.LinkTarget) ([]dto.LinkResult, error) {
+	store, err := loadCache(c.cfg.CachePath, c.cfg.CacheTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make([]dto.LinkTarget, 0, len(targets))
+	seen := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		if t.URL == "" || seen[t.URL] {
+			continue
+		}
+		seen[t.URL] = true
+		unique = append(unique, t)
+	}
+
+	results := make([]dto.LinkResult, len(unique))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < c.cfg.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				target := unique[i]
+				key := c.cacheKey(target)
+				if cached, ok := store.get(key, time.Now()); ok {
+					cached.Cached = true
+					results[i] = cached
+					continue
+				}
+				res := c.checkOne(ctx, target)
+				store.put(key, res)
+				results[i] = res
+			}
+		}()
+	}
+
+	for i := range unique {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := store.save(time.Now()); err != nil {
+		c.logger.Warn(fmt.Sprintf("Failed to save link cache: %v", err))
+	}
+
+	return results, nil
+}
+
+// cacheKey возвращает ключ кэша: результат зависит от типа ссылки и, для изображений,
+// от минимальных размеров, поэтому после изменения настроек ссылка проверяется заново
+func (c *Checker) cacheKey(target dto.LinkTarget) string {
+	if target.Kind == dto.LinkKindImage {
+		return fmt.Sprintf("%s %dx%d %s", target.Kind, c.cfg.MinImageWidth, c.cfg.MinImageHeight, target.URL)
+	}
+	return fmt.Sprintf("%s %s", target.Kind, target.URL)
+}
+
+// checkOne проверяет одну ссылку: HEAD, при неудаче — GET
+func (c *Checker) checkOne(ctx context.Context, target dto.LinkTarget) dto.LinkResult {
+	res := dto.LinkResult{
+		URL:       target.URL,
+		Kind:      target.Kind,
+		CheckedAt: time.Now().UTC(),
+	}
+
+	needDimensions := target.Kind == dto.LinkKindImage && (c.cfg.MinImageWidth > 0 || c.cfg.MinImageHeight > 0)
+
+	// HEAD достаточно, если не нужно читать размеры изображения
+	if !needDimensions {
+		resp, err := c.do(ctx, http.MethodHead, target.URL)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 400 {
+				c.fillResponse(&res, resp)
+				c.validate(&res)
+				return res
+			}
+			c.logger.Debug(fmt.Sprintf("HEAD %s returned %d, falling back to GET", target.URL, resp.StatusCode))
+		}
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, target.URL)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	defer resp.Body.Close()
+
+	c.fillResponse(&res, resp)
+	if resp.StatusCode < 400 && needDimensions {
+		cfg, _, err := image.DecodeConfig(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			res.Error = fmt.Sprintf("failed to decode image: %v", err)
+			return res
+		}
+		res.Width, res.Height = cfg.Width, cfg.Height
+	}
+	c.validate(&res)
+	return res
+}
+
+// do выполняет HTTP запрос
+func (c *Checker) do(ctx context.Context, method, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
+	return c.httpClient.Do(req)
+}
+
+// fillResponse переносит статус и тип содержимого в результат
+func (c *Checker) fillResponse(res *dto.LinkResult, resp *http.Response) {
+	res.StatusCode = resp.StatusCode
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		res.ContentType = mediaType
+	}
+}
+
+// validate проверяет статус, тип содержимого и размеры изображения
+func (c *Checker) validate(res *dto.LinkResult) {
+	switch {
+	case res.StatusCode >= 400:
+		res.Error = fmt.Sprintf("HTTP status %d", res.StatusCode)
+	case res.Kind == dto.LinkKindImage && res.ContentType != "" && !strings.HasPrefix(res.ContentType, "image/"):
+		res.Error = fmt.Sprintf("unexpected content type %q for image", res.ContentType)
+	case res.Kind == dto.LinkKindPage && res.ContentType != "" &&
+		res.ContentType != "text/html" && res.ContentType != "application/xhtml+xml":
+		res.Error = fmt.Sprintf("unexpected content type %q for page", res.ContentType)
+	case res.Width > 0 && res.Width < c.cfg.MinImageWidth,
+		res.Height > 0 && res.Height < c.cfg.MinImageHeight:
+		res.Error = fmt.Sprintf("image too small: %dx%d", res.Width, res.Height)
+	}
+	res.OK = res.Error == ""
+}
@@ -0,0 +1,21 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// WriteReport сохраняет отчёт о проверке ссылок в JSON файл
+func (c *Checker) WriteReport(path string, report dto.LinkCheckReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode link report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write link report: %w", err)
+	}
+	return nil
+}
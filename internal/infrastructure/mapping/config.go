@@ -0,0 +1,124 @@
+package mapping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownSource    = errors.New("unknown mapping source")
+	ErrUnknownTransform = errors.New("unknown mapping transform")
+	ErrEmptyField       = errors.New("mapping source requires a field")
+)
+
+// SourceKind определяет тип источника значения
+type SourceKind string
+
+const (
+	SourceCore  SourceKind = "core"  // Поле товара: id, name, vendorCode, itemCode
+	SourceInfo  SourceKind = "info"  // additionalInfo: description, fullDescription, fullName
+	SourceField SourceKind = "field" // Пользовательское поле по ID или названию
+	SourceTag   SourceKind = "tag"   // Тег товара; field — префикс, например "brand:"
+	SourceConst SourceKind = "const" // Постоянное значение value
+)
+
+// Целевые атрибуты entity.Product; остальные цели попадают в Product.Attributes
+const (
+	TargetName        = "name"
+	TargetVendor      = "vendor"
+	TargetBarcode     = "barcode"
+	TargetSKU         = "sku"
+	TargetDescription = "description"
+	TargetModel       = "model"
+	TargetTypePrefix  = "type_prefix"
+)
+
+// Source описывает один источник значения; список источников — цепочка fallback'ов
+type Source struct {
+	Source    SourceKind `json:"source"`
+	Field     string     `json:"field,omitempty"`
+	Value     string     `json:"value,omitempty"`
+	Transform []string   `json:"transform,omitempty"` // trim, lower, upper, strip_html, collapse_spaces, digits, truncate:N
+}
+
+// Config сопоставляет целевой атрибут со списком источников
+type Config map[string][]Source
+
+// DefaultConfig воспроизводит исходное поведение: vendorCode → vendor, itemCode → barcode
+func DefaultConfig() Config {
+	return Config{
+		TargetVendor:  {{Source: SourceCore, Field: "vendorCode"}},
+		TargetBarcode: {{Source: SourceCore, Field: "itemCode"}},
+	}
+}
+
+// LoadConfig загружает маппинг из JSON файла; заданные цели заменяют цели по умолчанию
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read field mapping: %w", err)
+	}
+
+	var custom Config
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("failed to parse field mapping %s: %w", path, err)
+	}
+
+	for target, sources := range custom {
+		if len(sources) == 0 {
+			// Пустой список отключает цель
+			delete(cfg, target)
+			continue
+		}
+		cfg[target] = sources
+	}
+	return cfg, nil
+}
+
+// Validate проверяет источники и преобразования
+func (c Config) Validate() error {
+	for target, sources := range c {
+		for _, src := range sources {
+			switch src.Source {
+			case SourceCore, SourceInfo, SourceField:
+				if src.Field == "" {
+					return fmt.Errorf("%w: target %s, source %s", ErrEmptyField, target, src.Source)
+				}
+			case SourceTag, SourceConst:
+			default:
+				return fmt.Errorf("%w: %q (target %s)", ErrUnknownSource, src.Source, target)
+			}
+			for _, t := range src.Transform {
+				if err := validateTransform(t); err != nil {
+					return fmt.Errorf("target %s: %w", target, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateTransform проверяет имя и аргумент преобразования
+func validateTransform(t string) error {
+	name, arg, _ := strings.Cut(t, ":")
+	switch name {
+	case "trim", "lower", "upper", "strip_html", "collapse_spaces", "digits":
+		return nil
+	case "truncate":
+		if n, err := strconv.Atoi(arg); err != nil || n <= 0 {
+			return fmt.Errorf("%w: %q requires a positive length", ErrUnknownTransform, t)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownTransform, t)
+	}
+}
@@ -0,0 +1,157 @@
+package mapping
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+var (
+	htmlTagRe    = regexp.MustCompile(`<[^>]*>`)
+	whitespaceRe = regexp.MustCompile(`\s+`)
+)
+
+// Record содержит значения исходных полей одного товара BeSeller
+type Record struct {
+	Core   map[string]string // id, name, vendorCode, itemCode
+	Info   map[string]string // description, fullDescription, fullName
+	Fields map[string]string // Пользовательские поля по ID
+	Names  map[string]string // Название/заголовок поля → ID
+	Tags   []string          // Названия тегов
+}
+
+// Mapper вычисляет атрибуты товара по декларативному маппингу
+type Mapper struct {
+	cfg Config
+}
+
+// NewMapper создаёт маппер и проверяет конфигурацию
+func NewMapper(cfg Config) (*Mapper, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Mapper{cfg: cfg}, nil
+}
+
+// UsesFieldNames сообщает, ссылается ли маппинг на пользовательские поля по названию
+func (m *Mapper) UsesFieldNames() bool {
+	for _, sources := range m.cfg {
+		for _, src := range sources {
+			if src.Source != SourceField {
+				continue
+			}
+			if _, err := strconv.Atoi(src.Field); err != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Resolve возвращает непустые значения целевых атрибутов, используя первый сработавший источник
+func (m *Mapper) Resolve(rec Record) map[string]string {
+	values := make(map[string]string, len(m.cfg))
+	for target, sources := range m.cfg {
+		for _, src := range sources {
+			if value := applyTransforms(lookup(rec, src), src.Transform); value != "" {
+				values[target] = value
+				break
+			}
+		}
+	}
+	return values
+}
+
+// Apply заполняет атрибуты товара значениями маппинга
+func (m *Mapper) Apply(rec Record, prod *entity.Product) {
+	for target, value := range m.Resolve(rec) {
+		v := value
+		switch target {
+		case TargetName:
+			prod.Name = v
+		case TargetVendor:
+			prod.Vendor = &v
+		case TargetBarcode:
+			prod.Barcode = &v
+		case TargetSKU:
+			prod.SKU = &v
+		case TargetDescription:
+			prod.Description = &v
+		case TargetModel:
+			prod.Model = v
+		case TargetTypePrefix:
+			prod.TypePrefix = v
+		default:
+			if prod.Attributes == nil {
+				prod.Attributes = make(map[string]string)
+			}
+			prod.Attributes[target] = v
+		}
+	}
+}
+
+// lookup извлекает сырое значение источника
+func lookup(rec Record, src Source) string {
+	switch src.Source {
+	case SourceCore:
+		return rec.Core[src.Field]
+	case SourceInfo:
+		return rec.Info[src.Field]
+	case SourceField:
+		if value, ok := rec.Fields[src.Field]; ok {
+			return value
+		}
+		if id, ok := rec.Names[strings.ToLower(src.Field)]; ok {
+			return rec.Fields[id]
+		}
+	case SourceTag:
+		for _, tag := range rec.Tags {
+			if src.Field == "" {
+				return tag
+			}
+			if len(tag) > len(src.Field) && strings.EqualFold(tag[:len(src.Field)], src.Field) {
+				return tag[len(src.Field):]
+			}
+		}
+	case SourceConst:
+		return src.Value
+	}
+	return ""
+}
+
+// applyTransforms применяет преобразования по порядку; значение всегда обрезается по краям
+func applyTransforms(value string, transforms []string) string {
+	for _, t := range transforms {
+		name, arg, _ := strings.Cut(t, ":")
+		switch name {
+		case "trim":
+			value = strings.TrimSpace(value)
+		case "lower":
+			value = strings.ToLower(value)
+		case "upper":
+			value = strings.ToUpper(value)
+		case "strip_html":
+			value = html.UnescapeString(htmlTagRe.ReplaceAllString(value, " "))
+		case "collapse_spaces":
+			value = whitespaceRe.ReplaceAllString(value, " ")
+		case "digits":
+			value = strings.Map(func(r rune) rune {
+				if unicode.IsDigit(r) {
+					return r
+				}
+				return -1
+			}, value)
+		case "truncate":
+			if n, err := strconv.Atoi(arg); err == nil {
+				if runes := []rune(value); len(runes) > n {
+					value = string(runes[:n])
+				}
+			}
+		}
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,81 @@
+package meta
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// RSS представляет XML фид каталога Meta (RSS 2.0 с пространством имён g:)
+type RSS struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	XMLNSG  string   `xml:"xmlns:g,attr"`
+	Channel Channel  `xml:"channel"`
+}
+
+// Channel представляет канал с товарами
+type Channel struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Items       []Item `xml:"item"`
+}
+
+// Item представляет товар каталога Meta
+type Item struct {
+	ID                    string   `xml:"g:id"`
+	Title                 string   `xml:"g:title"`
+	Description           string   `xml:"g:description"`
+	Availability          string   `xml:"g:availability"`
+	Condition             string   `xml:"g:condition"`
+	Price                 string   `xml:"g:price"`
+	Link                  string   `xml:"g:link"`
+	ImageLink             string   `xml:"g:image_link"`
+	AdditionalImageLink   []string `xml:"g:additional_image_link,omitempty"`
+	Brand                 string   `xml:"g:brand,omitempty"`
+	GTIN                  string   `xml:"g:gtin,omitempty"`
+	MPN                   string   `xml:"g:mpn,omitempty"`
+	GoogleProductCategory string   `xml:"g:google_product_category,omitempty"`
+	ProductType           string   `xml:"g:product_type,omitempty"`
+	ItemGroupID           string   `xml:"g:item_group_id,omitempty"`
+}
+
+// csvHeader — колонки CSV фида в порядке полей Item
+var csvHeader = []string{
+	"id",
+	"title",
+	"description",
+	"availability",
+	"condition",
+	"price",
+	"link",
+	"image_link",
+	"additional_image_link",
+	"brand",
+	"gtin",
+	"mpn",
+	"google_product_category",
+	"product_type",
+	"item_group_id",
+}
+
+// csvRow возвращает значения колонок CSV; дополнительные изображения перечисляются через запятую
+func (i Item) csvRow() []string {
+	return []string{
+		i.ID,
+		i.Title,
+		i.Description,
+		i.Availability,
+		i.Condition,
+		i.Price,
+		i.Link,
+		i.ImageLink,
+		strings.Join(i.AdditionalImageLink, ","),
+		i.Brand,
+		i.GTIN,
+		i.MPN,
+		i.GoogleProductCategory,
+		i.ProductType,
+		i.ItemGroupID,
+	}
+}
@@ -0,0 +1,221 @@
+package meta
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/categorymap"
+	"beseller-yml-exporter/internal/infrastructure/output"
+)
+
+// Format определяет формат файла каталога Meta
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatXML Format = "xml"
+)
+
+// Ограничения каталога Meta
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 9999
+	maxImages            = 21 // image_link и до 20 additional_image_link
+)
+
+// Значения availability
+const (
+	AvailabilityInStock          = "in stock"
+	AvailabilityOutOfStock       = "out of stock"
+	AvailabilityAvailableToOrder = "available for order"
+)
+
+// AttributeCondition — атрибут маппинга с состоянием товара (new, refurbished, used)
+const AttributeCondition = "condition"
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Options содержит настройки writer'а Meta
+type Options struct {
+	Format     Format             // csv или xml
+	Categories *categorymap.Table // Соответствие категорий google_product_category и google_product_type
+}
+
+// Writer реализует запись каталога Meta (Facebook/Instagram Commerce)
+type Writer struct {
+	logger Logger
+	opts   Options
+}
+
+// NewWriter создаёт новый writer каталога Meta
+func NewWriter(logger Logger, opts Options) *Writer {
+	if opts.Format == "" {
+		opts.Format = FormatCSV
+	}
+	if opts.Categories == nil {
+		opts.Categories = categorymap.NewTable(nil)
+	}
+	return &Writer{logger: logger, opts: opts}
+}
+
+// Accept пропускает только товары с изображением: image_link обязателен
+func (w *Writer) Accept(prod *entity.Product, tree *entity.CategoryTree) (bool, string) {
+	if len(prod.GetImageURLs()) == 0 {
+		return false, "meta catalog requires an image"
+	}
+	return true, ""
+}
+
+// UnmappedCategories возвращает категории товаров без google_product_category
+func (w *Writer) UnmappedCategories(tree *entity.CategoryTree, products []entity.Product) []string {
+	return w.opts.Categories.Unmapped(tree, products, categorymap.KeyGoogleProductCategory)
+}
+
+// Write записывает каталог Meta в CSV или XML файл
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	items := w.buildItems(source)
+	if w.opts.Format == FormatXML {
+		return output.WriteXML(outputPath, "", buildRSS(source.Shop, items))
+	}
+
+	file, err := output.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if err := writeCSV(file, items); err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+	return nil
+}
+
+// writeCSV записывает товары в CSV
+func writeCSV(file io.Writer, items []Item) error {
+	writer := csv.NewWriter(file)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for _, item := range items {
+		if err := writer.Write(item.csvRow()); err != nil {
+			return fmt.Errorf("failed to write item %s: %w", item.ID, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV: %w", err)
+	}
+	return nil
+}
+
+// buildRSS создаёт RSS документ с товарами
+func buildRSS(shop entity.Shop, items []Item) RSS {
+	return RSS{
+		Version: "2.0",
+		XMLNSG:  "http://base.google.com/ns/1.0",
+		Channel: Channel{
+			Title:       shop.Name,
+			Link:        shop.URL,
+			Description: shop.Company,
+			Items:       items,
+		},
+	}
+}
+
+// buildItems преобразует товары каталога в элементы фида Meta
+func (w *Writer) buildItems(source entity.Catalog) []Item {
+	tree := entity.NewCategoryTree(source.Categories)
+
+	items := make([]Item, 0, len(source.Products))
+	unmapped := 0
+	for i := range source.Products {
+		prod := &source.Products[i]
+
+		item := Item{
+			ID:           prod.ID,
+			Title:        output.Truncate(prod.Name, maxTitleLength),
+			Availability: availability(prod),
+			Condition:    condition(prod),
+			Price:        prod.Price.String() + " " + prod.Price.Currency(),
+			Link:         prod.URL,
+			ProductType:  strings.Join(tree.PathNames(prod.CategoryID), " > "),
+			ItemGroupID:  prod.GroupID,
+		}
+
+		// Описание обязательно: при его отсутствии используется название
+		item.Description = prod.Name
+		if prod.Description != nil && *prod.Description != "" {
+			item.Description = *prod.Description
+		}
+		item.Description = output.Truncate(item.Description, maxDescriptionLength)
+
+		// Изображения: общие правила URL и порядка, лимит Meta
+		images := prod.GetImageURLsLimit(maxImages)
+		if len(images) > 0 {
+			item.ImageLink = images[0]
+			item.AdditionalImageLink = images[1:]
+		}
+
+		if prod.Vendor != nil {
+			item.Brand = *prod.Vendor
+		}
+		if prod.Barcode != nil {
+			item.GTIN = *prod.Barcode
+		}
+		if prod.SKU != nil {
+			item.MPN = *prod.SKU
+		}
+
+		// product_type из таблицы соответствия заменяет путь категорий магазина
+		if productType, ok := w.opts.Categories.Resolve(tree, prod.CategoryID, categorymap.KeyGoogleProductType); ok {
+			item.ProductType = productType
+		}
+		if category, ok := w.opts.Categories.Resolve(tree, prod.CategoryID, categorymap.KeyGoogleProductCategory); ok {
+			item.GoogleProductCategory = category
+		} else {
+			unmapped++
+		}
+
+		items = append(items, item)
+	}
+
+	if unmapped > 0 {
+		w.logger.Warn(fmt.Sprintf("Meta catalog: %d items without google_product_category", unmapped))
+	}
+	return items
+}
+
+// availability возвращает значение availability по наличию и сроку поставки
+func availability(prod *entity.Product) string {
+	switch {
+	case prod.Available:
+		return AvailabilityInStock
+	case prod.DeliveryDays != nil && *prod.DeliveryDays > 0:
+		return AvailabilityAvailableToOrder
+	default:
+		return AvailabilityOutOfStock
+	}
+}
+
+// condition возвращает состояние товара из маппинга или new
+func condition(prod *entity.Product) string {
+	switch value := strings.ToLower(prod.Attribute(AttributeCondition)); value {
+	case "new", "refurbished", "used":
+		return value
+	default:
+		return "new"
+	}
+}
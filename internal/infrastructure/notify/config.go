@@ -0,0 +1,147 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Значения по умолчанию настроек уведомлений
+const (
+	defaultMaxOfferDropPercent = 20
+	defaultMaxSkippedPercent   = 10
+	defaultMinInterval         = 30 * time.Minute
+	defaultStatePath           = ".notify-state.json"
+)
+
+// Type определяет тип канала уведомлений
+type Type string
+
+const (
+	TypeWebhook  Type = "webhook"  // JSON POST на произвольный адрес
+	TypeSMTP     Type = "smtp"     // Письмо через SMTP сервер
+	TypeTelegram Type = "telegram" // Сообщение через Telegram Bot API (или совместимый API)
+)
+
+// SinkConfig описывает канал уведомлений. В строковых значениях подставляются
+// переменные окружения ${VAR}
+type SinkConfig struct {
+	Type        Type   `json:"type"`
+	MinInterval string `json:"min_interval"` // Переопределяет общий интервал между уведомлениями
+
+	// Webhook
+	URL     string            `json:"url"`     // Адрес, принимающий JSON уведомления
+	Headers map[string]string `json:"headers"` // Дополнительные заголовки, например Authorization
+
+	// SMTP
+	Host     string   `json:"host"`     // host:port SMTP сервера
+	Username string   `json:"username"` // Логин (пусто — без авторизации)
+	Password string   `json:"password"` // Пароль
+	From     string   `json:"from"`     // Отправитель
+	To       []string `json:"to"`       // Получатели
+	TLS      bool     `json:"tls"`      // Соединение сразу по TLS (порт 465); иначе STARTTLS, если сервер поддерживает
+
+	// Telegram
+	Token  string `json:"token"`   // Токен бота
+	ChatID string `json:"chat_id"` // Чат или канал
+	APIURL string `json:"api_url"` // Адрес Bot API (по умолчанию https://api.telegram.org)
+}
+
+// Config описывает уведомления о запусках
+type Config struct {
+	Sinks  map[string]SinkConfig   `json:"sinks"`  // Каналы по имени
+	Routes []dto.NotificationRoute `json:"routes"` // Маршруты (пусто — все фиды во все каналы)
+
+	MaxOfferDropPercent float64 `json:"max_offer_drop_percent"` // Аномальное падение числа предложений, % (по умолчанию 20)
+	MaxSkipped          int     `json:"max_skipped"`            // Аномальное число пропущенных товаров (0 — без порога)
+	MaxSkippedPercent   float64 `json:"max_skipped_percent"`    // Аномальная доля пропущенных товаров, % (по умолчанию 10)
+	MinInterval         string  `json:"min_interval"`           // Минимальный интервал между уведомлениями одного уровня в канал (по умолчанию 30m)
+	StatePath           string  `json:"state_path"`             // Файл с временем последних уведомлений
+}
+
+// LoadConfig загружает настройки уведомлений из JSON файла
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notifications config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse notifications config %s: %w", path, err)
+	}
+	if len(cfg.Sinks) == 0 {
+		return nil, fmt.Errorf("notifications config %s contains no sinks", path)
+	}
+
+	if cfg.MaxOfferDropPercent == 0 {
+		cfg.MaxOfferDropPercent = defaultMaxOfferDropPercent
+	}
+	if cfg.MaxSkippedPercent == 0 {
+		cfg.MaxSkippedPercent = defaultMaxSkippedPercent
+	}
+	if cfg.StatePath == "" {
+		cfg.StatePath = defaultStatePath
+	}
+	if len(cfg.Routes) == 0 {
+		for name := range cfg.Sinks {
+			cfg.Routes = append(cfg.Routes, dto.NotificationRoute{Sink: name})
+		}
+		sort.Slice(cfg.Routes, func(i, j int) bool { return cfg.Routes[i].Sink < cfg.Routes[j].Sink })
+	}
+
+	for _, route := range cfg.Routes {
+		if _, ok := cfg.Sinks[route.Sink]; !ok {
+			return nil, fmt.Errorf("notification route refers to unknown sink %q", route.Sink)
+		}
+		for _, level := range route.On {
+			switch level {
+			case dto.NotificationSuccess, dto.NotificationWarning, dto.NotificationFailure:
+			default:
+				return nil, fmt.Errorf("notification route %s: unknown level %q", route.Sink, level)
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+// Interval возвращает общий минимальный интервал между уведомлениями
+func (c *Config) Interval() (time.Duration, error) {
+	return parseInterval(c.MinInterval, defaultMinInterval)
+}
+
+// parseInterval разбирает интервал; пустая строка — значение по умолчанию
+func parseInterval(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid min_interval: %w", err)
+	}
+	return interval, nil
+}
+
+// expandEnv подставляет переменные окружения в строковые настройки
+func (c SinkConfig) expandEnv() SinkConfig {
+	for _, field := range []*string{
+		&c.URL, &c.Host, &c.Username, &c.Password, &c.From, &c.Token, &c.ChatID, &c.APIURL,
+	} {
+		*field = os.ExpandEnv(*field)
+	}
+	headers := make(map[string]string, len(c.Headers))
+	for key, value := range c.Headers {
+		headers[key] = os.ExpandEnv(value)
+	}
+	c.Headers = headers
+	to := make([]string, 0, len(c.To))
+	for _, addr := range c.To {
+		to = append(to, os.ExpandEnv(addr))
+	}
+	c.To = to
+	return c
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// sendTimeout — таймаут отправки одного уведомления
+const sendTimeout = 30 * time.Second
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// sender отправляет уведомление в конкретный канал
+type sender interface {
+	send(ctx context.Context, notification dto.Notification) error
+}
+
+// Sink — канал уведомлений с ограничением частоты: уведомление того же уровня
+// не отправляется в канал чаще, чем раз в интервал, в том числе между запусками
+type Sink struct {
+	name     string
+	sender   sender
+	interval time.Duration
+	state    *State
+	logger   Logger
+}
+
+// New создаёт канал уведомлений; interval — общий интервал, если в канале не задан свой
+func New(name string, cfg SinkConfig, interval time.Duration, state *State, logger Logger) (*Sink, error) {
+	cfg = cfg.expandEnv()
+
+	interval, err := parseInterval(cfg.MinInterval, interval)
+	if err != nil {
+		return nil, fmt.Errorf("sink %s: %w", name, err)
+	}
+
+	s := &Sink{name: name, interval: interval, state: state, logger: logger}
+	httpClient := &http.Client{Timeout: sendTimeout}
+	switch cfg.Type {
+	case TypeWebhook:
+		s.sender, err = newWebhookSender(cfg, httpClient)
+	case TypeSMTP:
+		s.sender, err = newSMTPSender(cfg)
+	case TypeTelegram:
+		s.sender, err = newTelegramSender(cfg, httpClient)
+	default:
+		err = fmt.Errorf("unknown type %q", cfg.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sink %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// Notify отправляет уведомление, если интервал с прошлого уведомления того же уровня истёк
+func (s *Sink) Notify(ctx context.Context, notification dto.Notification) error {
+	key := s.name + ":" + string(notification.Level)
+	if last, ok := s.state.LastSent(key); ok && time.Since(last) < s.interval {
+		s.logger.Info(fmt.Sprintf("Notification to %s suppressed by rate limit (last sent %s)", s.name, last.Format(time.RFC3339)))
+		return nil
+	}
+
+	if err := s.sender.send(ctx, notification); err != nil {
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("Notification (%s) sent to %s", notification.Level, s.name))
+	if err := s.state.MarkSent(key, time.Now()); err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to save notification state: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// State хранит время последних уведомлений по каналам и уровням в JSON файле,
+// чтобы ограничение частоты действовало между запусками
+type State struct {
+	path string
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+// LoadState загружает состояние; отсутствующий файл — пустое состояние
+func LoadState(path string) (*State, error) {
+	state := &State{path: path, sent: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state.sent); err != nil {
+		return nil, fmt.Errorf("failed to parse notification state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// LastSent возвращает время последнего уведомления по ключу
+func (s *State) LastSent(key string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last, ok := s.sent[key]
+	return last, ok
+}
+
+// MarkSent запоминает время уведомления и сохраняет состояние
+func (s *State) MarkSent(key string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[key] = at.UTC()
+
+	data, err := json.MarshalIndent(s.sent, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+const (
+	defaultTelegramAPIURL = "https://api.telegram.org"
+	telegramMaxText       = 4096 // Ограничение длины сообщения Bot API в символах
+)
+
+// telegramSender отправляет уведомление методом sendMessage Telegram Bot API
+type telegramSender struct {
+	endpoint   string
+	chatID     string
+	httpClient *http.Client
+}
+
+// telegramResponse — ответ Bot API
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// newTelegramSender создаёт канал Telegram; api_url позволяет использовать совместимый API
+func newTelegramSender(cfg SinkConfig, httpClient *http.Client) (*telegramSender, error) {
+	if cfg.Token == "" || cfg.ChatID == "" {
+		return nil, errors.New("token and chat_id are required for telegram sink")
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultTelegramAPIURL
+	}
+	return &telegramSender{
+		endpoint:   strings.TrimSuffix(apiURL, "/") + "/bot" + cfg.Token + "/sendMessage",
+		chatID:     cfg.ChatID,
+		httpClient: httpClient,
+	}, nil
+}
+
+// send отправляет текст уведомления без разметки, чтобы не экранировать причины пропуска
+func (s *telegramSender) send(ctx context.Context, notification dto.Notification) error {
+	text := []rune(notification.Text)
+	if len(text) > telegramMaxText {
+		text = append(text[:telegramMaxText-1], '…')
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id":                  s.chatID,
+		"text":                     string(text),
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		// Адрес запроса содержит токен бота, поэтому он не попадает в ошибку
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode telegram response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("telegram error %d: %s", resp.StatusCode, result.Description)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// webhookSender отправляет уведомление JSON POST запросом
+type webhookSender struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// newWebhookSender создаёт канал webhook
+func newWebhookSender(cfg SinkConfig, httpClient *http.Client) (*webhookSender, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("url is required for webhook sink")
+	}
+	return &webhookSender{url: cfg.URL, headers: cfg.Headers, httpClient: httpClient}, nil
+}
+
+// send отправляет уведомление целиком; успешными считаются ответы 2xx
+func (s *webhookSender) send(ctx context.Context, notification dto.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("HTTP error %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
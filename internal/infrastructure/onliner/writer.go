@@ -0,0 +1,155 @@
+package onliner
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/output"
+)
+
+// Currency — Onliner принимает прайс-листы только в белорусских рублях
+const Currency = "BYN"
+
+// Атрибуты товара из маппинга полей, используемые прайс-листом
+const (
+	AttributeWarranty = "warranty" // Гарантия, например "12 мес."
+	AttributeComment  = "comment"  // Комментарий продавца к предложению
+)
+
+// Колонки прайс-листа
+var header = []string{
+	"ID модели Onliner",
+	"ID типа Onliner",
+	"Артикул",
+	"Производитель",
+	"Наименование",
+	"Цена",
+	"Валюта",
+	"Наличие",
+	"Гарантия",
+	"Срок доставки, дн.",
+	"Стоимость доставки",
+	"Комментарий",
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Writer реализует запись прайс-листа Onliner.by (CSV с разделителем ";")
+type Writer struct {
+	logger Logger
+}
+
+// NewWriter создаёт новый writer прайс-листа Onliner
+func NewWriter(logger Logger) *Writer {
+	return &Writer{logger: logger}
+}
+
+// Accept пропускает только товары, привязанные к модели каталога Onliner
+func (w *Writer) Accept(prod *entity.Product, tree *entity.CategoryTree) (bool, string) {
+	switch {
+	case prod.OnlinerClassification.HasModel():
+		return true, ""
+	case prod.OnlinerClassification.HasType():
+		return false, "onliner model is not set (type only)"
+	default:
+		return false, "onliner classification is not set"
+	}
+}
+
+// Write записывает прайс-лист в CSV файл
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	if source.Shop.Currency != Currency {
+		return fmt.Errorf("onliner price list requires %s prices, feed currency is %s", Currency, source.Shop.Currency)
+	}
+
+	file, err := output.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Comma = ';'
+
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for i := range source.Products {
+		if err := writer.Write(w.buildRow(&source.Products[i], source.Shop.Delivery)); err != nil {
+			return fmt.Errorf("failed to write product %s: %w", source.Products[i].ID, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	w.logger.Debug(fmt.Sprintf("Written %d Onliner price list rows", len(source.Products)))
+	return nil
+}
+
+// buildRow формирует строку прайс-листа для товара
+func (w *Writer) buildRow(prod *entity.Product, terms *entity.DeliveryTerms) []string {
+	availability := "под заказ"
+	if prod.Available {
+		availability = "в наличии"
+	}
+
+	vendor := ""
+	if prod.Vendor != nil {
+		vendor = *prod.Vendor
+	}
+	sku := prod.ID
+	if prod.SKU != nil && *prod.SKU != "" {
+		sku = *prod.SKU
+	}
+
+	// Условия доставки: индивидуальные для товара или первая ступень магазина;
+	// без настроенных ступеней выводится только срок deliveryDays
+	days, cost := "", ""
+	if option, ok := deliveryOption(prod, terms); ok {
+		days = option.Days
+		cost = option.Cost.String()
+	} else if prod.DeliveryDays != nil {
+		days = strconv.Itoa(*prod.DeliveryDays)
+	}
+
+	return []string{
+		prod.OnlinerClassification.ModelID,
+		prod.OnlinerClassification.TypeID,
+		sku,
+		vendor,
+		prod.Name,
+		prod.Price.String(),
+		prod.Price.Currency(),
+		availability,
+		prod.Attribute(AttributeWarranty),
+		days,
+		cost,
+		prod.Attribute(AttributeComment),
+	}
+}
+
+// deliveryOption возвращает условия доставки товара
+func deliveryOption(prod *entity.Product, terms *entity.DeliveryTerms) (entity.DeliveryOption, bool) {
+	if len(prod.DeliveryOptions) > 0 {
+		return prod.DeliveryOptions[0], true
+	}
+	if terms != nil && terms.Delivery && len(terms.DeliveryOptions) > 0 {
+		return terms.DeliveryOptions[0], true
+	}
+	return entity.DeliveryOption{}, false
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Inspector вычисляет размер и контрольную сумму записанных файлов для отчёта о запуске
+type Inspector struct{}
+
+// NewInspector создаёт новый экземпляр Inspector
+func NewInspector() *Inspector {
+	return &Inspector{}
+}
+
+// Inspect возвращает размер файла и его SHA-256 (для .gz — сжатого файла, как он публикуется)
+func (i *Inspector) Inspect(path string) (dto.OutputFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return dto.OutputFile{}, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return dto.OutputFile{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return dto.OutputFile{
+		Path:   path,
+		Bytes:  size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GzipExt — расширение, включающее сжатие выходного файла
+const GzipExt = ".gz"
+
+// File — выходной файл фида; для путей с .gz данные сжимаются gzip
+type File struct {
+	io.Writer
+	file   *os.File
+	gz     *gzip.Writer
+	closed bool
+}
+
+// Create создаёт выходной файл; путь с расширением .gz включает сжатие
+func Create(path string) (*File, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &File{Writer: file, file: file}
+	if IsGzip(path) {
+		out.gz = gzip.NewWriter(file)
+		out.gz.Name = strings.TrimSuffix(filepath.Base(path), GzipExt)
+		out.Writer = out.gz
+	}
+	return out, nil
+}
+
+// WriteString записывает строку (для совместимости с *os.File)
+func (f *File) WriteString(s string) (int, error) {
+	return io.WriteString(f.Writer, s)
+}
+
+// Close завершает сжатие и закрывает файл; повторный вызов ничего не делает,
+// поэтому writer'ы могут и проверять ошибку Close, и закрывать файл в defer
+func (f *File) Close() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+
+	if f.gz != nil {
+		if err := f.gz.Close(); err != nil {
+			f.file.Close()
+			return fmt.Errorf("failed to finish gzip stream: %w", err)
+		}
+	}
+	return f.file.Close()
+}
+
+// IsGzip проверяет, включено ли сжатие для пути
+func IsGzip(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), GzipExt)
+}
+
+// PartPath возвращает путь n-й части: feed.yml.gz → feed-2.yml.gz
+func PartPath(path string, n int) string {
+	gz := ""
+	if IsGzip(path) {
+		gz = path[len(path)-len(GzipExt):]
+		path = path[:len(path)-len(GzipExt)]
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d%s%s", strings.TrimSuffix(path, ext), n, ext, gz)
+}
+
+// RemoveStaleParts удаляет части outputPath с номерами от from, оставшиеся от предыдущих
+// запусков с большим числом частей, и возвращает пути удалённых файлов
+func RemoveStaleParts(outputPath string, from int) ([]string, error) {
+	var removed []string
+	for n := from; ; n++ {
+		path := PartPath(outputPath, n)
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return removed, nil
+		}
+		if err != nil {
+			return removed, fmt.Errorf("failed to remove stale part: %w", err)
+		}
+		removed = append(removed, path)
+	}
+}
@@ -0,0 +1,165 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Splitter записывает фид несколькими самостоятельными файлами
+// с ограничением числа предложений и размера, а также манифест частей
+type Splitter struct {
+	logger Logger
+}
+
+// manifest — содержимое файла со списком частей фида
+type manifest struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Offers      int            `json:"offers"`
+	Parts       []manifestPart `json:"parts"`
+}
+
+// manifestPart — часть фида в манифесте; путь указывается относительно манифеста
+type manifestPart struct {
+	File   string `json:"file"`
+	Offers int    `json:"offers"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// NewSplitter создаёт новый экземпляр Splitter
+func NewSplitter(logger Logger) *Splitter {
+	return &Splitter{logger: logger}
+}
+
+// WriteParts записывает каталог частями feed-1.yml, feed-2.yml, ... и манифест
+// feed.manifest.json рядом с ними. Часть, превысившая MaxBytes, перезаписывается
+// с меньшим числом предложений; для файлов .gz учитывается сжатый размер.
+// Каждая следующая часть начинается с числа предложений предыдущей и дополняется,
+// только если заметно не достигает лимита, поэтому фид не перерисовывается целиком
+// для каждой части. write — метод Write writer'а фида.
+func (s *Splitter) WriteParts(write func(string, entity.Catalog) error, outputPath string, catalog entity.Catalog, split dto.SplitConfig) ([]dto.FeedPart, string, error) {
+	var parts []dto.FeedPart
+	remaining := catalog.Products
+	size := len(remaining) // первая часть — все предложения, следующие — как предыдущая
+	for len(parts) == 0 || len(remaining) > 0 {
+		limit := len(remaining)
+		if split.MaxOffers > 0 && limit > split.MaxOffers {
+			limit = split.MaxOffers
+		}
+		if size > limit {
+			size = limit
+		}
+
+		path := PartPath(outputPath, len(parts)+1)
+		shrunk := false
+		for {
+			if err := write(path, catalog.Part(remaining[:size])); err != nil {
+				return nil, "", fmt.Errorf("part %d: %w", len(parts)+1, err)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, "", fmt.Errorf("part %d: %w", len(parts)+1, err)
+			}
+
+			if split.MaxBytes == 0 || info.Size() <= split.MaxBytes {
+				// Часть меньше 80% лимита дополняется, пока её не приходилось уменьшать
+				if !shrunk && size > 0 && size < limit && info.Size()*10 < split.MaxBytes*8 {
+					if next := min(fitOffers(size, info.Size(), split.MaxBytes), limit); next > size {
+						s.logger.Debug(fmt.Sprintf("Part %s is %d bytes, rewriting with %d offers", path, info.Size(), next))
+						size = next
+						continue
+					}
+				}
+				parts = append(parts, dto.FeedPart{Path: path, Offers: size, Bytes: info.Size()})
+				break
+			}
+			if size <= 1 {
+				return nil, "", fmt.Errorf("part %d: single offer takes %d bytes, limit is %d", len(parts)+1, info.Size(), split.MaxBytes)
+			}
+
+			next := fitOffers(size, info.Size(), split.MaxBytes)
+			if next >= size {
+				next = size - 1
+			}
+			if next < 1 {
+				next = 1
+			}
+			s.logger.Debug(fmt.Sprintf("Part %s is %d bytes, rewriting with %d of %d offers", path, info.Size(), next, size))
+			size = next
+			shrunk = true
+		}
+		remaining = remaining[size:]
+	}
+
+	if err := s.removeStale(outputPath, len(parts)+1); err != nil {
+		return nil, "", err
+	}
+
+	manifestPath := ManifestPath(outputPath)
+	if err := writeManifest(manifestPath, len(catalog.Products), parts); err != nil {
+		return nil, "", err
+	}
+	return parts, manifestPath, nil
+}
+
+// fitOffers оценивает число предложений, помещающихся в лимит, по размеру части
+// из size предложений; оценка берётся с небольшим запасом
+func fitOffers(size int, bytes, maxBytes int64) int {
+	return int(float64(size) * float64(maxBytes) / float64(bytes) * 0.95)
+}
+
+// removeStale удаляет части, оставшиеся от предыдущих запусков с большим числом частей
+func (s *Splitter) removeStale(outputPath string, from int) error {
+	removed, err := RemoveStaleParts(outputPath, from)
+	for _, path := range removed {
+		s.logger.Debug(fmt.Sprintf("Removed stale part %s", path))
+	}
+	return err
+}
+
+// writeManifest записывает список частей в JSON
+func writeManifest(path string, offers int, parts []dto.FeedPart) error {
+	m := manifest{
+		GeneratedAt: time.Now().UTC(),
+		Offers:      offers,
+		Parts:       make([]manifestPart, 0, len(parts)),
+	}
+	for _, part := range parts {
+		m.Parts = append(m.Parts, manifestPart{
+			File:   filepath.Base(part.Path),
+			Offers: part.Offers,
+			Bytes:  part.Bytes,
+		})
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// ManifestPath возвращает путь манифеста частей: feed.yml.gz → feed.manifest.json
+func ManifestPath(path string) string {
+	if IsGzip(path) {
+		path = path[:len(path)-len(GzipExt)]
+	}
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".manifest.json"
+}
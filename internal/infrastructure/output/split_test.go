@@ -0,0 +1,191 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// writeLines записывает по строке фиксированной длины на товар
+func writeLines(path string, catalog entity.Catalog) error {
+	file, err := Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, prod := range catalog.Products {
+		if _, err := file.WriteString(prod.ID + strings.Repeat(".", 99-len(prod.ID)) + "\n"); err != nil {
+			return err
+		}
+	}
+	return file.Close()
+}
+
+func testCatalog(n int) entity.Catalog {
+	products := make([]entity.Product, n)
+	for i := range products {
+		products[i].ID = string(rune('a' + i))
+	}
+	return entity.Catalog{Products: products}
+}
+
+func partOffers(parts []dto.FeedPart) []int {
+	offers := make([]int, 0, len(parts))
+	for _, part := range parts {
+		offers = append(offers, part.Offers)
+	}
+	return offers
+}
+
+func TestWritePartsByOffers(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "feed.yml")
+	// Часть от предыдущего запуска с большим числом частей
+	stale := PartPath(outputPath, 4)
+	if err := os.WriteFile(stale, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	parts, manifestPath, err := NewSplitter(nopLogger{}).WriteParts(writeLines, outputPath, testCatalog(5), dto.SplitConfig{MaxOffers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := partOffers(parts); len(got) != 3 || got[0] != 2 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("part offers = %v, want [2 2 1]", got)
+	}
+	if parts[0].Path != filepath.Join(dir, "feed-1.yml") || parts[0].Bytes != 200 {
+		t.Errorf("first part = %+v", parts[0])
+	}
+	if _, err := os.Stat(stale); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stale part was not removed: %v", err)
+	}
+
+	if manifestPath != filepath.Join(dir, "feed.manifest.json") {
+		t.Errorf("manifest = %s", manifestPath)
+	}
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Offers != 5 || len(m.Parts) != 3 || m.Parts[2].File != "feed-3.yml" {
+		t.Errorf("manifest = %+v", m)
+	}
+}
+
+func TestWritePartsByBytes(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "feed.yml")
+
+	parts, _, err := NewSplitter(nopLogger{}).WriteParts(writeLines, outputPath, testCatalog(7), dto.SplitConfig{MaxBytes: 250})
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, part := range parts {
+		if part.Bytes > 250 {
+			t.Errorf("part %s is %d bytes, limit 250", part.Path, part.Bytes)
+		}
+		total += part.Offers
+	}
+	if total != 7 {
+		t.Errorf("offers in parts = %d, want 7", total)
+	}
+}
+
+func TestWritePartsByBytesRendersEachPartOnce(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "feed.yml")
+	writes, rendered := 0, 0
+	counting := func(path string, catalog entity.Catalog) error {
+		writes++
+		rendered += len(catalog.Products)
+		return writeLines(path, catalog)
+	}
+
+	// 1000 предложений по 100 байт при лимите 10000 байт — не меньше 10 частей
+	parts, _, err := NewSplitter(nopLogger{}).WriteParts(counting, outputPath, testCatalog(1000), dto.SplitConfig{MaxBytes: 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) < 10 {
+		t.Fatalf("parts = %d, want at least 10", len(parts))
+	}
+	if writes > len(parts)+2 {
+		t.Errorf("writes = %d for %d parts", writes, len(parts))
+	}
+	if rendered > 2*1000+100 {
+		t.Errorf("rendered %d offers for a 1000 offer catalog", rendered)
+	}
+}
+
+func TestWritePartsByBytesGrowsSmallParts(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "feed.yml")
+	// Первые 5 предложений занимают по 300 байт, остальные — по 100
+	catalog := testCatalog(20)
+	write := func(path string, part entity.Catalog) error {
+		file, err := Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		for _, prod := range part.Products {
+			size := 100
+			if prod.ID < "f" {
+				size = 300
+			}
+			if _, err := file.WriteString(strings.Repeat(".", size-1) + "\n"); err != nil {
+				return err
+			}
+		}
+		return file.Close()
+	}
+
+	parts, _, err := NewSplitter(nopLogger{}).WriteParts(write, outputPath, catalog, dto.SplitConfig{MaxBytes: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Части после крупных предложений дополняются до лимита, а не остаются по 3 предложения
+	if got := partOffers(parts); len(got) != 4 || got[0] != 3 || got[1] != 4 || got[2] != 9 || got[3] != 4 {
+		t.Errorf("part offers = %v, want [3 4 9 4]", got)
+	}
+}
+
+func TestWritePartsSingleOfferTooLarge(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "feed.yml")
+
+	_, _, err := NewSplitter(nopLogger{}).WriteParts(writeLines, outputPath, testCatalog(2), dto.SplitConfig{MaxBytes: 50})
+	if err == nil || !strings.Contains(err.Error(), "single offer") {
+		t.Errorf("err = %v, want single offer error", err)
+	}
+}
+
+func TestPartAndManifestPath(t *testing.T) {
+	tests := []struct{ path, part, manifest string }{
+		{"feed.yml", "feed-2.yml", "feed.manifest.json"},
+		{"out/google.yml.gz", "out/google-2.yml.gz", "out/google.manifest.json"},
+		{"meta.csv", "meta-2.csv", "meta.manifest.json"},
+	}
+	for _, tt := range tests {
+		if got := PartPath(tt.path, 2); got != tt.part {
+			t.Errorf("PartPath(%q) = %q, want %q", tt.path, got, tt.part)
+		}
+		if got := ManifestPath(tt.path); got != tt.manifest {
+			t.Errorf("ManifestPath(%q) = %q, want %q", tt.path, got, tt.manifest)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package output
+
+// Truncate обрезает строку до limit символов (рун); limit <= 0 — без ограничения
+func Truncate(s string, limit int) string {
+	if limit <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit])
+}
+
+// FirstNonEmpty возвращает первую непустую строку
+func FirstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrEmptyFile возвращается для пустого выходного файла
+var ErrEmptyFile = errors.New("file is empty")
+
+// Verify проверяет целостность записанного файла перед публикацией: файл не пуст,
+// поток gzip завершён, XML (.xml, .yml) и JSON синтаксически корректны
+func Verify(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return ErrEmptyFile
+	}
+
+	var r io.Reader = file
+	name := path
+	if IsGzip(path) {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("invalid gzip stream: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+		name = path[:len(path)-len(GzipExt)]
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xml", ".yml":
+		decoder := xml.NewDecoder(r)
+		// Проверяется только синтаксис, поэтому объявленная кодировка не перекодируется
+		decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+			return input, nil
+		}
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				return fmt.Errorf("invalid XML: %w", err)
+			}
+		}
+	case ".json":
+		var v interface{}
+		if err := json.NewDecoder(r).Decode(&v); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+
+	// Дочитывание проверяет контрольную сумму gzip
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+
+	// Сжатые файлы записываются через Create, как их пишут writer'ы
+	writeGzip := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		file, err := Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := file.WriteString(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := file.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	writePlain := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	valid := []string{
+		writePlain("ok.yml", `<?xml version="1.0" encoding="windows-1251"?><yml_catalog/>`),
+		writePlain("ok.json", `{"parts": []}`),
+		writePlain("ok.csv", "id,title\n"),
+		writeGzip("ok.xml.gz", "<rss/>"),
+	}
+	for _, path := range valid {
+		if err := Verify(path); err != nil {
+			t.Errorf("Verify(%s) = %v, want nil", filepath.Base(path), err)
+		}
+	}
+
+	if err := Verify(writePlain("empty.yml", "")); !errors.Is(err, ErrEmptyFile) {
+		t.Errorf("empty file: err = %v, want ErrEmptyFile", err)
+	}
+
+	truncated := writeGzip("cut.yml.gz", "<yml_catalog/>")
+	data, err := os.ReadFile(truncated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(truncated, data[:len(data)-4], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []string{
+		writePlain("bad.xml", "<rss><channel></rss>"),
+		writePlain("bad.json", `{"parts": [`),
+		writePlain("plain.yml.gz", "<yml_catalog/>"),
+		writeGzip("bad.yml.gz", "<yml_catalog>"),
+		truncated,
+	}
+	for _, path := range invalid {
+		if err := Verify(path); err == nil {
+			t.Errorf("Verify(%s) = nil, want error", filepath.Base(path))
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// WriteXML записывает документ в XML файл с отступами (для путей .gz — со сжатием).
+// header выводится сразу после XML заголовка, например DOCTYPE; пустой — не выводится
+func WriteXML(path, header string, doc interface{}) error {
+	file, err := Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(xml.Header + header); err != nil {
+		return fmt.Errorf("failed to write XML header: %w", err)
+	}
+
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode XML: %w", err)
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return fmt.Errorf("failed to flush encoder: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// httpUploader загружает файлы HTTP запросом PUT или POST с телом-файлом
+type httpUploader struct {
+	url        string
+	method     string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// newHTTPUploader создаёт публикацию по HTTP
+func newHTTPUploader(cfg Config, timeout time.Duration) (*httpUploader, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("url is required for http publisher")
+	}
+	method := strings.ToUpper(cfg.Method)
+	switch method {
+	case "":
+		method = http.MethodPut
+	case http.MethodPut, http.MethodPost:
+	default:
+		return nil, fmt.Errorf("unsupported method %q, use PUT or POST", cfg.Method)
+	}
+	return &httpUploader{
+		url:        cfg.URL,
+		method:     method,
+		headers:    cfg.Headers,
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// upload отправляет файл; успешными считаются ответы 2xx
+func (u *httpUploader) upload(ctx context.Context, localPath, name string, meta Metadata) (string, error) {
+	target := u.targetURL(name)
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, u.method, target, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", meta.ContentType)
+	if meta.CacheControl != "" {
+		req.Header.Set("Cache-Control", meta.CacheControl)
+	}
+	for key, value := range u.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("HTTP error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return target, nil
+}
+
+// targetURL подставляет путь файла в шаблон {file} или добавляет его к адресу
+func (u *httpUploader) targetURL(name string) string {
+	escaped := (&url.URL{Path: name}).EscapedPath()
+	if strings.Contains(u.url, "{file}") {
+		return strings.ReplaceAll(u.url, "{file}", escaped)
+	}
+	return strings.TrimSuffix(u.url, "/") + "/" + escaped
+}
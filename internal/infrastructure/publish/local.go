@@ -0,0 +1,59 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// localUploader копирует файлы в локальный каталог (например, каталог веб-сервера)
+type localUploader struct {
+	dir string
+}
+
+// newLocalUploader создаёт публикацию в локальный каталог
+func newLocalUploader(cfg Config) (*localUploader, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("dir is required for local publisher")
+	}
+	return &localUploader{dir: cfg.Dir}, nil
+}
+
+// upload копирует файл через временный файл в том же каталоге, чтобы читатели
+// никогда не видели частично записанный фид
+func (u *localUploader) upload(_ context.Context, localPath, name string, _ Metadata) (string, error) {
+	dest := filepath.Join(u.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	src, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to copy file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", fmt.Errorf("failed to replace file: %w", err)
+	}
+	return dest, nil
+}
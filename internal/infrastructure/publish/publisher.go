@@ -0,0 +1,218 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"beseller-yml-exporter/internal/infrastructure/output"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Type определяет способ публикации
+type Type string
+
+const (
+	TypeLocal Type = "local" // Копирование в локальный каталог
+	TypeSFTP  Type = "sftp"  // Загрузка на SFTP сервер
+	TypeS3    Type = "s3"    // Загрузка в S3-совместимое хранилище
+	TypeHTTP  Type = "http"  // Загрузка HTTP запросом PUT или POST
+)
+
+// Значения по умолчанию для повторных попыток
+const (
+	defaultAttempts   = 3
+	defaultRetryDelay = 2 * time.Second
+)
+
+// Config описывает публикатор из раздела publishers файла фидов.
+// В строковых значениях подставляются переменные окружения ${VAR},
+// поэтому пароли и ключи не обязательно хранить в файле.
+type Config struct {
+	Type         Type   `json:"type"`
+	Attempts     int    `json:"attempts"`      // Попыток загрузки файла (по умолчанию 3)
+	RetryDelay   string `json:"retry_delay"`   // Пауза перед повтором, растёт с номером попытки (по умолчанию 2s)
+	ContentType  string `json:"content_type"`  // Content-Type по умолчанию (пусто — по расширению файла)
+	CacheControl string `json:"cache_control"` // Cache-Control по умолчанию
+
+	Dir string `json:"dir"` // local, sftp: корневой каталог; s3: префикс ключей
+
+	// SFTP
+	Host                  string `json:"host"`        // host:port (порт по умолчанию 22)
+	User                  string `json:"user"`        // Пользователь
+	Password              string `json:"password"`    // Пароль
+	KeyPath               string `json:"key"`         // Закрытый ключ OpenSSH
+	KeyPassphrase         string `json:"passphrase"`  // Пароль закрытого ключа
+	KnownHosts            string `json:"known_hosts"` // Файл known_hosts для проверки ключа сервера
+	InsecureIgnoreHostKey bool   `json:"insecure_ignore_host_key"`
+
+	// S3
+	Endpoint  string `json:"endpoint"`   // https://storage.yandexcloud.net, http://localhost:9000 (MinIO)
+	Region    string `json:"region"`     // Регион подписи (по умолчанию us-east-1)
+	Bucket    string `json:"bucket"`     // Бакет
+	AccessKey string `json:"access_key"` // Ключ доступа
+	SecretKey string `json:"secret_key"` // Секретный ключ
+	PathStyle bool   `json:"path_style"` // Адрес бакета в пути (endpoint/bucket/key), нужен для MinIO
+
+	// HTTP
+	URL     string            `json:"url"`     // Адрес загрузки; {file} заменяется путём файла, иначе путь добавляется к адресу
+	Method  string            `json:"method"`  // PUT (по умолчанию) или POST
+	Headers map[string]string `json:"headers"` // Дополнительные заголовки, например Authorization
+
+	Timeout string `json:"timeout"` // Таймаут загрузки одного файла для sftp, s3 и http (по умолчанию 5m)
+}
+
+// Metadata содержит метаданные загружаемого файла
+type Metadata struct {
+	ContentType  string
+	CacheControl string
+}
+
+// uploader загружает файл под именем name относительно корня места назначения
+// и возвращает адрес опубликованного файла
+type uploader interface {
+	upload(ctx context.Context, localPath, name string, meta Metadata) (string, error)
+}
+
+// Publisher публикует файлы фида с проверкой целостности и повторными попытками
+type Publisher struct {
+	name         string
+	uploader     uploader
+	attempts     int
+	retryDelay   time.Duration
+	contentType  string
+	cacheControl string
+	logger       Logger
+
+	after func(time.Duration) <-chan time.Time // ожидание перед повтором (time.After)
+}
+
+// New создаёт публикатор по настройкам
+func New(name string, cfg Config, logger Logger) (*Publisher, error) {
+	cfg = cfg.expandEnv()
+
+	p := &Publisher{
+		name:         name,
+		attempts:     cfg.Attempts,
+		retryDelay:   defaultRetryDelay,
+		contentType:  cfg.ContentType,
+		cacheControl: cfg.CacheControl,
+		logger:       logger,
+		after:        time.After,
+	}
+	if p.attempts <= 0 {
+		p.attempts = defaultAttempts
+	}
+	if cfg.RetryDelay != "" {
+		delay, err := time.ParseDuration(cfg.RetryDelay)
+		if err != nil {
+			return nil, fmt.Errorf("publisher %s: invalid retry_delay: %w", name, err)
+		}
+		p.retryDelay = delay
+	}
+	timeout := 5 * time.Minute
+	if cfg.Timeout != "" {
+		parsed, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("publisher %s: invalid timeout: %w", name, err)
+		}
+		timeout = parsed
+	}
+
+	var err error
+	switch cfg.Type {
+	case TypeLocal:
+		p.uploader, err = newLocalUploader(cfg)
+	case TypeSFTP:
+		p.uploader, err = newSFTPUploader(cfg, timeout)
+	case TypeS3:
+		p.uploader, err = newS3Uploader(cfg, timeout)
+	case TypeHTTP:
+		p.uploader, err = newHTTPUploader(cfg, timeout)
+	default:
+		err = fmt.Errorf("unknown type %q", cfg.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("publisher %s: %w", name, err)
+	}
+	return p, nil
+}
+
+// Publish проверяет файл и загружает его, повторяя попытки при ошибках
+func (p *Publisher) Publish(ctx context.Context, localPath string, target dto.PublishTarget) (string, error) {
+	if err := output.Verify(localPath); err != nil {
+		return "", fmt.Errorf("file %s failed verification: %w", localPath, err)
+	}
+
+	name := path.Join(target.Dir, filepath.Base(localPath))
+	meta := Metadata{
+		ContentType:  output.FirstNonEmpty(target.ContentType, p.contentType, ContentType(localPath)),
+		CacheControl: output.FirstNonEmpty(target.CacheControl, p.cacheControl),
+	}
+
+	for attempt := 1; ; attempt++ {
+		location, err := p.uploader.upload(ctx, localPath, name, meta)
+		if err == nil {
+			return location, nil
+		}
+		if attempt >= p.attempts {
+			return "", fmt.Errorf("failed to upload %s after %d attempts: %w", name, attempt, err)
+		}
+
+		p.logger.Warn(fmt.Sprintf("Publisher %s: upload of %s failed, retrying... (attempt %d/%d): %v", p.name, name, attempt, p.attempts, err))
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-p.after(p.retryDelay * time.Duration(attempt)):
+		}
+	}
+}
+
+// ContentType определяет Content-Type файла фида по расширению
+func ContentType(localPath string) string {
+	if output.IsGzip(localPath) {
+		return "application/gzip"
+	}
+	switch ext := strings.ToLower(filepath.Ext(localPath)); ext {
+	case ".xml", ".yml":
+		return "application/xml; charset=utf-8"
+	case ".csv":
+		return "text/csv; charset=utf-8"
+	case ".json":
+		return "application/json"
+	default:
+		if contentType := mime.TypeByExtension(ext); contentType != "" {
+			return contentType
+		}
+		return "application/octet-stream"
+	}
+}
+
+// expandEnv подставляет переменные окружения в строковые настройки
+func (c Config) expandEnv() Config {
+	for _, field := range []*string{
+		&c.Dir, &c.Host, &c.User, &c.Password, &c.KeyPath, &c.KeyPassphrase, &c.KnownHosts,
+		&c.Endpoint, &c.Region, &c.Bucket, &c.AccessKey, &c.SecretKey, &c.URL,
+	} {
+		*field = os.ExpandEnv(*field)
+	}
+	headers := make(map[string]string, len(c.Headers))
+	for key, value := range c.Headers {
+		headers[key] = os.ExpandEnv(value)
+	}
+	c.Headers = headers
+	return c
+}
@@ -0,0 +1,198 @@
+package publish
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// fakeUploader завершается ошибкой заданное число раз, затем успешно
+type fakeUploader struct {
+	failures int
+	calls    int
+	names    []string
+	meta     Metadata
+}
+
+func (u *fakeUploader) upload(_ context.Context, _, name string, meta Metadata) (string, error) {
+	u.calls++
+	u.names = append(u.names, name)
+	u.meta = meta
+	if u.calls <= u.failures {
+		return "", errors.New("connection reset")
+	}
+	return "fake://" + name, nil
+}
+
+// newTestPublisher создаёт публикатор с фиктивной загрузкой и записью пауз между попытками
+func newTestPublisher(up *fakeUploader, attempts int, delays *[]time.Duration) *Publisher {
+	return &Publisher{
+		name:       "test",
+		uploader:   up,
+		attempts:   attempts,
+		retryDelay: 2 * time.Second,
+		logger:     nopLogger{},
+		after: func(d time.Duration) <-chan time.Time {
+			*delays = append(*delays, d)
+			ch := make(chan time.Time, 1)
+			ch <- time.Time{}
+			return ch
+		},
+	}
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func gzipData(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestPublishRetriesWithGrowingDelay(t *testing.T) {
+	localPath := writeFile(t, "feed.yml", []byte("<yml_catalog/>"))
+	up := &fakeUploader{failures: 2}
+	var delays []time.Duration
+	p := newTestPublisher(up, 3, &delays)
+
+	location, err := p.Publish(context.Background(), localPath, dto.PublishTarget{Publisher: "test", Dir: "google"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location != "fake://google/feed.yml" {
+		t.Errorf("location = %q", location)
+	}
+	if up.calls != 3 {
+		t.Errorf("upload calls = %d, want 3", up.calls)
+	}
+	if want := []time.Duration{2 * time.Second, 4 * time.Second}; !equalDurations(delays, want) {
+		t.Errorf("delays = %v, want %v", delays, want)
+	}
+	if up.meta.ContentType != "application/xml; charset=utf-8" {
+		t.Errorf("content type = %q", up.meta.ContentType)
+	}
+}
+
+func TestPublishGivesUpAfterAttempts(t *testing.T) {
+	localPath := writeFile(t, "feed.yml", []byte("<yml_catalog/>"))
+	up := &fakeUploader{failures: 10}
+	var delays []time.Duration
+	p := newTestPublisher(up, 3, &delays)
+
+	_, err := p.Publish(context.Background(), localPath, dto.PublishTarget{Publisher: "test"})
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Fatalf("err = %v, want failure after 3 attempts", err)
+	}
+	if up.calls != 3 {
+		t.Errorf("upload calls = %d, want 3", up.calls)
+	}
+	if len(delays) != 2 {
+		t.Errorf("delays = %v, want 2 pauses", delays)
+	}
+}
+
+func TestPublishStopsOnCancel(t *testing.T) {
+	localPath := writeFile(t, "feed.yml", []byte("<yml_catalog/>"))
+	up := &fakeUploader{failures: 10}
+	p := &Publisher{
+		name: "test", uploader: up, attempts: 5, retryDelay: time.Hour, logger: nopLogger{},
+		after: time.After,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Publish(ctx, localPath, dto.PublishTarget{Publisher: "test"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if up.calls != 1 {
+		t.Errorf("upload calls = %d, want 1", up.calls)
+	}
+}
+
+func TestPublishRejectsInvalidFiles(t *testing.T) {
+	valid := gzipData(t, "<yml_catalog/>")
+	tests := []struct {
+		name string
+		file string
+		data []byte
+	}{
+		{name: "empty", file: "feed.yml", data: nil},
+		{name: "broken XML", file: "feed.xml", data: []byte("<rss><channel></rss>")},
+		{name: "truncated XML", file: "feed.yml", data: []byte("<yml_catalog><shop>")},
+		{name: "broken JSON", file: "feed.manifest.json", data: []byte(`{"parts": [`)},
+		{name: "not gzip", file: "feed.yml.gz", data: []byte("<yml_catalog/>")},
+		{name: "truncated gzip", file: "feed.yml.gz", data: valid[:len(valid)-6]},
+		{name: "broken XML in gzip", file: "feed.yml.gz", data: gzipData(t, "<yml_catalog>")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localPath := writeFile(t, tt.file, tt.data)
+			up := &fakeUploader{}
+			var delays []time.Duration
+			p := newTestPublisher(up, 3, &delays)
+
+			_, err := p.Publish(context.Background(), localPath, dto.PublishTarget{Publisher: "test"})
+			if err == nil || !strings.Contains(err.Error(), "failed verification") {
+				t.Errorf("err = %v, want verification failure", err)
+			}
+			if up.calls != 0 {
+				t.Errorf("upload calls = %d, want 0", up.calls)
+			}
+		})
+	}
+}
+
+func TestPublishAcceptsValidGzip(t *testing.T) {
+	localPath := writeFile(t, "feed.yml.gz", gzipData(t, "<yml_catalog/>"))
+	up := &fakeUploader{}
+	var delays []time.Duration
+	p := newTestPublisher(up, 3, &delays)
+
+	if _, err := p.Publish(context.Background(), localPath, dto.PublishTarget{Publisher: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if up.meta.ContentType != "application/gzip" {
+		t.Errorf("content type = %q, want application/gzip", up.meta.ContentType)
+	}
+}
+
+func equalDurations(a, b []time.Duration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sftpUploader загружает файлы на SFTP сервер
+type sftpUploader struct {
+	addr      string
+	dir       string
+	sshConfig *ssh.ClientConfig
+}
+
+// newSFTPUploader создаёт публикацию на SFTP сервер. Ключ сервера проверяется
+// по known_hosts; отключить проверку можно только явно
+func newSFTPUploader(cfg Config, timeout time.Duration) (*sftpUploader, error) {
+	if cfg.Host == "" || cfg.User == "" {
+		return nil, errors.New("host and user are required for sftp publisher")
+	}
+	addr := cfg.Host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+
+	var auth []ssh.AuthMethod
+	if cfg.KeyPath != "" {
+		key, err := os.ReadFile(cfg.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key: %w", err)
+		}
+		var signer ssh.Signer
+		if cfg.KeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(cfg.KeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auth = append(auth, ssh.Password(cfg.Password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("password or key is required for sftp publisher")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	switch {
+	case cfg.KnownHosts != "":
+		callback, err := knownhosts.New(cfg.KnownHosts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load known_hosts: %w", err)
+		}
+		hostKeyCallback = callback
+	case cfg.InsecureIgnoreHostKey:
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	default:
+		return nil, errors.New("known_hosts is required for sftp publisher (or insecure_ignore_host_key)")
+	}
+
+	return &sftpUploader{
+		addr: addr,
+		dir:  cfg.Dir,
+		sshConfig: &ssh.ClientConfig{
+			User:            cfg.User,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         timeout,
+		},
+	}, nil
+}
+
+// upload загружает файл во временный файл и переименовывает его,
+// чтобы площадка не забрала частично загруженный фид
+func (u *sftpUploader) upload(ctx context.Context, localPath, name string, _ Metadata) (string, error) {
+	conn, err := u.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to start sftp session: %w", err)
+	}
+	defer client.Close()
+
+	// Соединение закрывается при отмене контекста, прерывая передачу
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	dest := path.Join(u.dir, name)
+	if err := client.MkdirAll(path.Dir(dest)); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	src, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	tmp := path.Join(path.Dir(dest), "."+path.Base(dest)+".tmp")
+	remote, err := client.Create(tmp)
+	if err != nil {
+		return "", fmt.Errorf("failed to create remote file: %w", err)
+	}
+	if _, err := io.Copy(remote, src); err != nil {
+		remote.Close()
+		client.Remove(tmp)
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	if err := remote.Close(); err != nil {
+		client.Remove(tmp)
+		return "", fmt.Errorf("failed to close remote file: %w", err)
+	}
+	if err := rename(client, tmp, dest); err != nil {
+		client.Remove(tmp)
+		return "", fmt.Errorf("failed to rename remote file: %w", err)
+	}
+	return fmt.Sprintf("sftp://%s%s", u.addr, path.Join("/", dest)), nil
+}
+
+// posixRenameExtension — расширение OpenSSH, заменяющее существующий файл при переименовании
+const posixRenameExtension = "posix-rename@openssh.com"
+
+// rename заменяет dest файлом tmp. Без расширения posix-rename переименование по
+// протоколу SFTP не перезаписывает файл, поэтому прежний dest сначала удаляется
+func rename(client *sftp.Client, tmp, dest string) error {
+	if _, ok := client.HasExtension(posixRenameExtension); ok {
+		return client.PosixRename(tmp, dest)
+	}
+	if err := client.Remove(dest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to replace %s: %w", dest, err)
+	}
+	return client.Rename(tmp, dest)
+}
+
+// dial устанавливает SSH соединение с учётом контекста
+func (u *sftpUploader) dial(ctx context.Context) (*ssh.Client, error) {
+	dialer := net.Dialer{Timeout: u.sshConfig.Timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", u.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, u.addr, u.sshConfig)
+	if err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("ssh handshake failed: %w", err)
+	}
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
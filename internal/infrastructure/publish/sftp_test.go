@@ -0,0 +1,191 @@
+package publish
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// startSFTPServer запускает SFTP сервер в процессе теста с корнем root
+// и возвращает его адрес и файл known_hosts с ключом сервера
+func startSFTPServer(t *testing.T, root string) (string, string) {
+	t.Helper()
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "feeds" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSFTP(conn, config, root)
+		}
+	}()
+
+	addr := listener.Addr().String()
+	return addr, writeKnownHosts(t, addr, signer.PublicKey())
+}
+
+// writeKnownHosts записывает known_hosts с ключом key для адреса addr
+func writeKnownHosts(t *testing.T, addr string, key ssh.PublicKey) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{addr}, key) + "\n"
+	if err := os.WriteFile(path, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// serveSFTP обслуживает SSH соединение с подсистемой sftp
+func serveSFTP(conn net.Conn, config *ssh.ServerConfig, root string) {
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range requests {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				req.Reply(ok, nil)
+				if !ok {
+					continue
+				}
+				server, err := sftp.NewServer(channel, sftp.WithServerWorkingDirectory(root))
+				if err != nil {
+					channel.Close()
+					return
+				}
+				server.Serve()
+				channel.Close()
+			}
+		}()
+	}
+}
+
+func testSFTPPublish(t *testing.T) {
+	root := t.TempDir()
+	addr, knownHosts := startSFTPServer(t, root)
+	p, err := New("sftp", Config{
+		Type:       TypeSFTP,
+		Host:       addr,
+		User:       "feeds",
+		Password:   "secret",
+		KnownHosts: knownHosts,
+		Dir:        "public",
+		Timeout:    "10s",
+	}, nopLogger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Второй запуск заменяет опубликованный файл
+	for _, content := range []string{"<yml_catalog>old</yml_catalog>", "<yml_catalog>new</yml_catalog>"} {
+		localPath := writeFile(t, "feed.yml", []byte(content))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		location, err := p.Publish(ctx, localPath, dto.PublishTarget{Publisher: "sftp", Dir: "google"})
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "sftp://" + addr + "/public/google/feed.yml"; location != want {
+			t.Errorf("location = %q, want %q", location, want)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "public", "google", "feed.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "<yml_catalog>new</yml_catalog>" {
+		t.Errorf("published file = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(root, "public", "google", ".feed.yml.tmp")); !os.IsNotExist(err) {
+		t.Errorf("temporary file left on server: %v", err)
+	}
+}
+
+func TestSFTPPublish(t *testing.T) {
+	testSFTPPublish(t)
+}
+
+func TestSFTPPublishWithoutPosixRename(t *testing.T) {
+	// Сервер без расширения posix-rename@openssh.com
+	if err := sftp.SetSFTPExtensions("statvfs@openssh.com"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sftp.SetSFTPExtensions("hardlink@openssh.com", "posix-rename@openssh.com", "statvfs@openssh.com")
+	})
+	testSFTPPublish(t)
+}
+
+func TestSFTPRejectsUnknownHostKey(t *testing.T) {
+	addr, _ := startSFTPServer(t, t.TempDir())
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ssh.NewPublicKey(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKnownHosts := writeKnownHosts(t, addr, publicKey)
+
+	p, err := New("sftp", Config{
+		Type: TypeSFTP, Host: addr, User: "feeds", Password: "secret",
+		KnownHosts: otherKnownHosts, Attempts: 1, Timeout: "10s",
+	}, nopLogger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localPath := writeFile(t, "feed.yml", []byte("<yml_catalog/>"))
+	if _, err := p.Publish(context.Background(), localPath, dto.PublishTarget{Publisher: "sftp"}); err == nil {
+		t.Error("publish succeeded with an unknown host key")
+	}
+}
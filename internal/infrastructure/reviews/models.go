@@ -0,0 +1,111 @@
+package reviews
+
+import "encoding/xml"
+
+// Feed представляет корневой элемент Google Product Reviews (схема 2.3)
+type Feed struct {
+	XMLName        xml.Name  `xml:"feed"`
+	XMLNSVC        string    `xml:"xmlns:vc,attr"`
+	XMLNSXSI       string    `xml:"xmlns:xsi,attr"`
+	SchemaLocation string    `xml:"xsi:noNamespaceSchemaLocation,attr"`
+	Version        string    `xml:"version"`
+	Publisher      Publisher `xml:"publisher"`
+	Reviews        Reviews   `xml:"reviews"`
+}
+
+// Publisher представляет источник отзывов
+type Publisher struct {
+	Name string `xml:"name"`
+}
+
+// Reviews представляет список отзывов
+type Reviews struct {
+	Review []Review `xml:"review"`
+}
+
+// Review представляет отзыв
+type Review struct {
+	ReviewID        string    `xml:"review_id"`
+	Reviewer        Reviewer  `xml:"reviewer"`
+	ReviewTimestamp string    `xml:"review_timestamp"`
+	Content         string    `xml:"content"`
+	Pros            *Pros     `xml:"pros,omitempty"`
+	Cons            *Cons     `xml:"cons,omitempty"`
+	ReviewURL       ReviewURL `xml:"review_url"`
+	Ratings         Ratings   `xml:"ratings"`
+	Products        Products  `xml:"products"`
+}
+
+// Reviewer представляет автора отзыва
+type Reviewer struct {
+	Name       ReviewerName `xml:"name"`
+	ReviewerID string       `xml:"reviewer_id,omitempty"`
+}
+
+// ReviewerName представляет имя автора; анонимные отзывы помечаются атрибутом
+type ReviewerName struct {
+	IsAnonymous string `xml:"is_anonymous,attr,omitempty"`
+	Value       string `xml:",chardata"`
+}
+
+// Pros представляет достоинства товара
+type Pros struct {
+	Pro []string `xml:"pro"`
+}
+
+// Cons представляет недостатки товара
+type Cons struct {
+	Con []string `xml:"con"`
+}
+
+// ReviewURL представляет страницу с отзывом
+type ReviewURL struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Ratings представляет оценки отзыва
+type Ratings struct {
+	Overall Overall `xml:"overall"`
+}
+
+// Overall представляет общую оценку
+type Overall struct {
+	Min   int `xml:"min,attr"`
+	Max   int `xml:"max,attr"`
+	Value int `xml:",chardata"`
+}
+
+// Products представляет товары, к которым относится отзыв
+type Products struct {
+	Product []Product `xml:"product"`
+}
+
+// Product представляет товар отзыва
+type Product struct {
+	ProductIDs  ProductIDs `xml:"product_ids"`
+	ProductName string     `xml:"product_name,omitempty"`
+	ProductURL  string     `xml:"product_url"`
+}
+
+// ProductIDs представляет идентификаторы товара
+type ProductIDs struct {
+	GTINs  *GTINs  `xml:"gtins,omitempty"`
+	SKUs   SKUs    `xml:"skus"`
+	Brands *Brands `xml:"brands,omitempty"`
+}
+
+// GTINs представляет штрих-коды товара
+type GTINs struct {
+	GTIN []string `xml:"gtin"`
+}
+
+// SKUs представляет артикулы товара (ID предложения в фидах магазина)
+type SKUs struct {
+	SKU []string `xml:"sku"`
+}
+
+// Brands представляет производителей товара
+type Brands struct {
+	Brand []string `xml:"brand"`
+}
@@ -0,0 +1,134 @@
+package reviews
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/output"
+)
+
+// Параметры схемы Google Product Reviews
+const (
+	schemaVersion  = "2.3"
+	schemaLocation = "http://www.google.com/shopping/reviews/schema/product/2.3/product_reviews.xsd"
+	anonymousName  = "Anonymous"
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Writer реализует запись отзывов в формат Google Product Reviews
+type Writer struct {
+	logger Logger
+}
+
+// NewWriter создаёт новый writer отзывов
+func NewWriter(logger Logger) *Writer {
+	return &Writer{logger: logger}
+}
+
+// UsesReviews сообщает use case, что формату нужны отзывы о товарах
+func (w *Writer) UsesReviews() bool {
+	return true
+}
+
+// Write записывает отзывы каталога в XML файл
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	feed := w.buildFeed(source)
+
+	return output.WriteXML(outputPath, "", feed)
+}
+
+// buildFeed создаёт структуру фида отзывов
+func (w *Writer) buildFeed(source entity.Catalog) Feed {
+	feed := Feed{
+		XMLNSVC:        "http://www.w3.org/2007/XMLSchema-versioning",
+		XMLNSXSI:       "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: schemaLocation,
+		Version:        schemaVersion,
+		Publisher:      Publisher{Name: source.Shop.Name},
+	}
+
+	products := make(map[string]*entity.Product, len(source.Products))
+	for i := range source.Products {
+		products[source.Products[i].ID] = &source.Products[i]
+	}
+
+	feed.Reviews.Review = make([]Review, 0, len(source.Reviews))
+	for _, rev := range source.Reviews {
+		prod, ok := products[rev.ProductID]
+		if !ok {
+			w.logger.Debug(fmt.Sprintf("Skipping review %s: product %s is not in the feed", rev.ID, rev.ProductID))
+			continue
+		}
+
+		// content обязателен: при пустом тексте используются достоинства или недостатки
+		content := output.FirstNonEmpty(rev.Comment, rev.Positive, rev.Negative)
+		if content == "" || !rev.HasValidMark() {
+			w.logger.Debug(fmt.Sprintf("Skipping review %s: empty content or invalid mark", rev.ID))
+			continue
+		}
+
+		review := Review{
+			ReviewID:        rev.ID,
+			ReviewTimestamp: reviewTimestamp(rev.CreatedAt),
+			Content:         content,
+			ReviewURL:       ReviewURL{Type: "group", Value: prod.URL},
+			Ratings: Ratings{Overall: Overall{
+				Min:   entity.MinReviewMark,
+				Max:   entity.MaxReviewMark,
+				Value: rev.Mark,
+			}},
+		}
+
+		review.Reviewer.Name.Value = rev.Name
+		if rev.Name == "" {
+			review.Reviewer.Name = ReviewerName{IsAnonymous: "true", Value: anonymousName}
+		}
+		review.Reviewer.ReviewerID = rev.AuthorID
+
+		if rev.Positive != "" && rev.Positive != content {
+			review.Pros = &Pros{Pro: []string{rev.Positive}}
+		}
+		if rev.Negative != "" && rev.Negative != content {
+			review.Cons = &Cons{Con: []string{rev.Negative}}
+		}
+
+		review.Products.Product = []Product{buildProduct(prod)}
+		feed.Reviews.Review = append(feed.Reviews.Review, review)
+	}
+
+	w.logger.Debug(fmt.Sprintf("Built %d of %d reviews", len(feed.Reviews.Review), len(source.Reviews)))
+	return feed
+}
+
+// buildProduct формирует идентификаторы товара: GTIN из штрих-кода, SKU — ID предложения
+func buildProduct(prod *entity.Product) Product {
+	product := Product{
+		ProductName: prod.Name,
+		ProductURL:  prod.URL,
+	}
+	product.ProductIDs.SKUs.SKU = []string{prod.ID}
+	if prod.Barcode != nil && strings.TrimSpace(*prod.Barcode) != "" {
+		product.ProductIDs.GTINs = &GTINs{GTIN: []string{strings.TrimSpace(*prod.Barcode)}}
+	}
+	if prod.Vendor != nil && *prod.Vendor != "" {
+		product.ProductIDs.Brands = &Brands{Brand: []string{*prod.Vendor}}
+	}
+	return product
+}
+
+// reviewTimestamp форматирует дату отзыва в ISO 8601; без даты используется текущее время
+func reviewTimestamp(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,65 @@
+package shopby
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// ClassificationMode повторяет ShopByClassificationEnum из схемы BeSeller
+type ClassificationMode string
+
+// Отбор по режиму выполняет API, значения передаются в ProductFilter.shopByClassification
+const (
+	ClassificationAny   ClassificationMode = "any"   // Все товары
+	ClassificationModel ClassificationMode = "model" // Привязанные к модели
+	ClassificationType  ClassificationMode = "type"  // Привязанные к типу
+	ClassificationNot   ClassificationMode = "not"   // Без классификации
+)
+
+// Validate проверяет режим классификации
+func (m ClassificationMode) Validate() error {
+	switch m {
+	case ClassificationAny, ClassificationModel, ClassificationType, ClassificationNot:
+		return nil
+	default:
+		return fmt.Errorf("invalid shop.by classification %q (any, model, type, not)", m)
+	}
+}
+
+// attributesFile представляет JSON файл обязательных атрибутов:
+// {"required": {"<typeId>": [<attributeId>, ...]}}
+type attributesFile struct {
+	Required map[string][]int `json:"required"`
+}
+
+// LoadRequiredAttributes загружает обязательные атрибуты по типам Shop.by.
+// Пустой путь означает отсутствие требований.
+func LoadRequiredAttributes(path string) (map[string][]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shop.by attributes: %w", err)
+	}
+
+	var file attributesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse shop.by attributes %s: %w", path, err)
+	}
+
+	required := make(map[string][]string, len(file.Required))
+	for typeID, attributes := range file.Required {
+		ids := make([]string, 0, len(attributes))
+		for _, id := range attributes {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		sort.Strings(ids)
+		required[typeID] = ids
+	}
+	return required, nil
+}
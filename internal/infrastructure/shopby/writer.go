@@ -0,0 +1,156 @@
+package shopby
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/output"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Options содержит настройки writer'а Shop.by
+type Options struct {
+	Classification     ClassificationMode  // Какие товары выгружать (ShopByClassificationEnum)
+	RequiredAttributes map[string][]string // Обязательные атрибуты по ID типа Shop.by
+	MaxPictures        int                 // Максимум <picture> в offer (0 — без ограничения)
+}
+
+// Writer реализует запись фида Shop.by
+type Writer struct {
+	logger Logger
+	opts   Options
+}
+
+// NewWriter создаёт новый writer Shop.by
+func NewWriter(logger Logger, opts Options) (*Writer, error) {
+	if opts.Classification == "" {
+		opts.Classification = ClassificationModel
+	}
+	if err := opts.Classification.Validate(); err != nil {
+		return nil, err
+	}
+	return &Writer{logger: logger, opts: opts}, nil
+}
+
+// ProductFilter возвращает отбор товаров по классификации Shop.by на стороне API
+// (ProductFilter.shopByClassification); режим any не ограничивает товары
+func (w *Writer) ProductFilter() dto.ProductFilter {
+	if w.opts.Classification == ClassificationAny {
+		return dto.ProductFilter{}
+	}
+	return dto.ProductFilter{ShopByClassification: string(w.opts.Classification)}
+}
+
+// Accept проверяет обязательные атрибуты типа Shop.by
+func (w *Writer) Accept(prod *entity.Product, tree *entity.CategoryTree) (bool, string) {
+	if prod.ShopByClassification.HasType() {
+		if missing := w.missingAttributes(prod); len(missing) > 0 {
+			return false, "missing required shop.by attributes: " + strings.Join(missing, ", ")
+		}
+	}
+	return true, ""
+}
+
+// missingAttributes возвращает обязательные атрибуты типа, для которых у товара нет значения
+func (w *Writer) missingAttributes(prod *entity.Product) []string {
+	required := w.opts.RequiredAttributes[prod.ShopByClassification.TypeID]
+	if len(required) == 0 {
+		return nil
+	}
+
+	present := make(map[string]bool, len(prod.ShopByTypeValues))
+	for _, value := range prod.ShopByTypeValues {
+		if value.DictionaryID != "" && value.DictionaryID != "0" {
+			present[value.AttributeID] = true
+		}
+	}
+
+	var missing []string
+	for _, attributeID := range required {
+		if !present[attributeID] {
+			missing = append(missing, attributeID)
+		}
+	}
+	return missing
+}
+
+// Write записывает фид Shop.by в XML файл
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	catalog := w.buildCatalog(source)
+
+	return output.WriteXML(outputPath, "", catalog)
+}
+
+// buildCatalog создаёт структуру фида Shop.by
+func (w *Writer) buildCatalog(source entity.Catalog) Catalog {
+	catalog := Catalog{
+		Date: time.Now().Format("2006-01-02 15:04"),
+		Shop: Shop{
+			Name:    source.Shop.Name,
+			Company: source.Shop.Company,
+			URL:     source.Shop.URL,
+		},
+	}
+	catalog.Shop.Currencies.Currency = []Currency{{ID: source.Shop.Currency, Rate: "1"}}
+
+	catalog.Shop.Categories.Category = make([]Category, 0, len(source.Categories))
+	for _, cat := range source.Categories {
+		catalog.Shop.Categories.Category = append(catalog.Shop.Categories.Category, Category{
+			ID:       cat.ID,
+			ParentID: cat.ParentID,
+			Name:     cat.Name,
+		})
+	}
+
+	catalog.Shop.Offers.Offer = make([]Offer, 0, len(source.Products))
+	for _, prod := range source.Products {
+		offer := Offer{
+			ID:         prod.ID,
+			Available:  strconv.FormatBool(prod.Available),
+			URL:        prod.URL,
+			Price:      prod.Price.String(),
+			CurrencyID: prod.Price.Currency(),
+			CategoryID: prod.CategoryID,
+			Picture:    prod.GetImageURLsLimit(w.opts.MaxPictures),
+			Name:       prod.Name,
+		}
+
+		if prod.Vendor != nil && *prod.Vendor != "" {
+			offer.Vendor = *prod.Vendor
+		}
+		if prod.SKU != nil && *prod.SKU != "" {
+			offer.VendorCode = *prod.SKU
+		}
+		if prod.Barcode != nil && *prod.Barcode != "" {
+			offer.Barcode = *prod.Barcode
+		}
+		if prod.Description != nil && *prod.Description != "" {
+			offer.Description = *prod.Description
+		}
+
+		// Классификация и значения атрибутов из справочников Shop.by
+		if class := prod.ShopByClassification; class != nil {
+			offer.TypeID = class.TypeID
+			offer.ModelID = class.ModelID
+		}
+		for _, value := range prod.ShopByTypeValues {
+			offer.Attribute = append(offer.Attribute, Attribute{ID: value.AttributeID, Value: value.DictionaryID})
+		}
+
+		catalog.Shop.Offers.Offer = append(catalog.Shop.Offers.Offer, offer)
+	}
+
+	w.logger.Debug(fmt.Sprintf("Built %d Shop.by offers", len(catalog.Shop.Offers.Offer)))
+	return catalog
+}
@@ -0,0 +1,32 @@
+package sitemap
+
+import "encoding/xml"
+
+// Пространства имён протокола Sitemaps и расширения изображений Google
+const (
+	namespace      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	imageNamespace = "http://www.google.com/schemas/sitemap-image/1.1"
+)
+
+// URL представляет элемент url карты сайта
+type URL struct {
+	XMLName xml.Name `xml:"url"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod,omitempty"`
+	Images  []Image  `xml:"image:image,omitempty"`
+}
+
+// Image представляет изображение страницы (расширение image:image);
+// заголовок и подпись выводятся при IMAGE_INCLUDE_META
+type Image struct {
+	Loc     string `xml:"image:loc"`
+	Title   string `xml:"image:title,omitempty"`
+	Caption string `xml:"image:caption,omitempty"`
+}
+
+// IndexEntry представляет элемент sitemap индекса
+type IndexEntry struct {
+	XMLName xml.Name `xml:"sitemap"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod,omitempty"`
+}
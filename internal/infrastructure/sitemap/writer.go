@@ -0,0 +1,253 @@
+package sitemap
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/output"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Лимиты одного файла по протоколу Sitemaps
+const (
+	MaxURLs  = 50000
+	MaxBytes = 50 * 1024 * 1024
+
+	maxImagesPerURL = 1000
+)
+
+// lastModLayout — lastmod в формате W3C Datetime
+const lastModLayout = time.RFC3339
+
+// Открывающие и закрывающие теги файлов
+const (
+	urlsetOpen  = `<urlset xmlns="` + namespace + `" xmlns:image="` + imageNamespace + `">` + "\n"
+	urlsetClose = "</urlset>\n"
+	indexOpen   = `<sitemapindex xmlns="` + namespace + `">` + "\n"
+	indexClose  = "</sitemapindex>\n"
+)
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Options содержит настройки карты сайта
+type Options struct {
+	BaseURL  string // Адрес каталога с частями карты для sitemap индекса (пусто — URL магазина)
+	MaxURLs  int    // Максимум URL в одном файле (0 — MaxURLs)
+	MaxBytes int    // Максимальный размер одного файла (0 — MaxBytes)
+}
+
+// Writer формирует sitemap.xml по страницам категорий и товаров
+type Writer struct {
+	logger Logger
+	opts   Options
+}
+
+// NewWriter создаёт writer карты сайта
+func NewWriter(logger Logger, opts Options) *Writer {
+	if opts.MaxURLs <= 0 || opts.MaxURLs > MaxURLs {
+		opts.MaxURLs = MaxURLs
+	}
+	if opts.MaxBytes <= 0 || opts.MaxBytes > MaxBytes {
+		opts.MaxBytes = MaxBytes
+	}
+	return &Writer{logger: logger, opts: opts}
+}
+
+// part — содержимое одного файла карты
+type part struct {
+	entries [][]byte
+	size    int
+	lastMod *time.Time
+}
+
+// Write записывает карту сайта; при превышении лимитов создаются части
+// name-1.xml, name-2.xml, ... и индекс в outputPath. Путь .gz сжимает все файлы,
+// лимит размера при этом считается по несжатым данным, как требует протокол
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	_, err := w.WriteParts(outputPath, source)
+	return err
+}
+
+// WriteParts записывает карту сайта как Write и возвращает части, на которые
+// она разбита (пусто, если карта уместилась в outputPath целиком)
+func (w *Writer) WriteParts(outputPath string, source entity.Catalog) ([]dto.FeedPart, error) {
+	urls := w.collect(source)
+
+	parts, err := w.split(urls)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parts) == 1 {
+		if err := writeFile(outputPath, urlsetOpen, parts[0].entries, urlsetClose); err != nil {
+			return nil, err
+		}
+		if err := w.removeStale(outputPath, 1); err != nil {
+			return nil, err
+		}
+		w.logger.Debug(fmt.Sprintf("Written sitemap with %d URLs", len(urls)))
+		return nil, nil
+	}
+
+	baseURL := strings.TrimRight(w.opts.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = strings.TrimRight(source.Shop.URL, "/")
+	}
+
+	index := make([][]byte, 0, len(parts))
+	written := make([]dto.FeedPart, 0, len(parts))
+	for i, p := range parts {
+		partPath := output.PartPath(outputPath, i+1)
+		if err := writeFile(partPath, urlsetOpen, p.entries, urlsetClose); err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(partPath)
+		if err != nil {
+			return nil, err
+		}
+		written = append(written, dto.FeedPart{Path: partPath, Offers: len(p.entries), Bytes: info.Size()})
+
+		entry := IndexEntry{Loc: baseURL + "/" + filepath.Base(partPath)}
+		if p.lastMod != nil {
+			entry.LastMod = p.lastMod.Format(lastModLayout)
+		}
+		data, err := xml.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode sitemap index entry: %w", err)
+		}
+		index = append(index, append(data, '\n'))
+	}
+
+	if err := writeFile(outputPath, indexOpen, index, indexClose); err != nil {
+		return nil, err
+	}
+	if err := w.removeStale(outputPath, len(parts)+1); err != nil {
+		return nil, err
+	}
+	w.logger.Debug(fmt.Sprintf("Written sitemap index with %d parts and %d URLs", len(parts), len(urls)))
+	return written, nil
+}
+
+// removeStale удаляет части, оставшиеся от предыдущих запусков с большим числом частей,
+// чтобы поисковые роботы и публикация не получали устаревшие файлы
+func (w *Writer) removeStale(outputPath string, from int) error {
+	removed, err := output.RemoveStaleParts(outputPath, from)
+	for _, path := range removed {
+		w.logger.Debug(fmt.Sprintf("Removed stale sitemap part %s", path))
+	}
+	return err
+}
+
+// entry — URL страницы с датой изменения
+type entry struct {
+	url     URL
+	updated *time.Time
+}
+
+// collect отбирает индексируемые страницы категорий и товаров без повторов
+func (w *Writer) collect(source entity.Catalog) []entry {
+	seen := make(map[string]bool)
+	urls := make([]entry, 0, len(source.Categories)+len(source.Products))
+
+	add := func(loc string, page entity.PageMeta, images []entity.Image) {
+		if loc == "" || page.NoIndex || seen[loc] {
+			return
+		}
+		seen[loc] = true
+
+		item := URL{Loc: loc}
+		if page.UpdatedAt != nil {
+			item.LastMod = page.UpdatedAt.Format(lastModLayout)
+		}
+		if len(images) > maxImagesPerURL {
+			images = images[:maxImagesPerURL]
+		}
+		for _, image := range images {
+			if image.URL == "" {
+				continue
+			}
+			item.Images = append(item.Images, Image{Loc: image.URL, Title: image.Title, Caption: image.Description})
+		}
+		urls = append(urls, entry{url: item, updated: page.UpdatedAt})
+	}
+
+	for _, cat := range source.Categories {
+		add(cat.URL, cat.Page, nil)
+	}
+	for i := range source.Products {
+		prod := &source.Products[i]
+		add(prod.URL, prod.Page, prod.Images)
+	}
+	return urls
+}
+
+// split кодирует URL и раскладывает их по частям с учётом лимитов количества и размера
+func (w *Writer) split(urls []entry) ([]*part, error) {
+	overhead := len(xml.Header) + len(urlsetOpen) + len(urlsetClose)
+	current := &part{size: overhead}
+	parts := []*part{current}
+
+	for _, u := range urls {
+		data, err := xml.Marshal(u.url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode sitemap URL %s: %w", u.url.Loc, err)
+		}
+		data = append(data, '\n')
+
+		if overhead+len(data) > w.opts.MaxBytes {
+			return nil, fmt.Errorf("sitemap URL %s exceeds %d bytes", u.url.Loc, w.opts.MaxBytes)
+		}
+		if len(current.entries) >= w.opts.MaxURLs || current.size+len(data) > w.opts.MaxBytes {
+			current = &part{size: overhead}
+			parts = append(parts, current)
+		}
+
+		current.entries = append(current.entries, data)
+		current.size += len(data)
+		if u.updated != nil && (current.lastMod == nil || u.updated.After(*current.lastMod)) {
+			current.lastMod = u.updated
+		}
+	}
+	return parts, nil
+}
+
+// writeFile записывает XML файл из готовых фрагментов
+func writeFile(path, opening string, entries [][]byte, closing string) error {
+	file, err := output.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	buf := bufio.NewWriter(file)
+	if _, err := buf.WriteString(xml.Header + opening); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	for _, data := range entries {
+		if _, err := buf.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if _, err := buf.WriteString(closing); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package urlbuilder
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// DefaultImageTemplate - шаблон URL изображений BeSeller по умолчанию
+const DefaultImageTemplate = "{cdn}/pics/items/{image}"
+
+// ImageConfig содержит настройки построения URL изображений
+type ImageConfig struct {
+	Template    string // Шаблон URL, плейсхолдеры {shop}, {cdn}, {image}, {id}
+	CDNBaseURL  string // Базовый URL CDN; если пуст, {cdn} совпадает с {shop}
+	IncludeMeta bool   // Переносить ли imageTitle/imageDescription (alt-текст)
+}
+
+// ImageSource представляет исходные данные изображения (PageImage)
+type ImageSource struct {
+	Image       string // Имя файла или абсолютный URL
+	Position    int    // Порядок изображения
+	Title       string // imageTitle
+	Description string // imageDescription
+}
+
+// ImageURLBuilder строит упорядоченный список изображений товара без дублей
+type ImageURLBuilder struct {
+	shop string
+	cdn  string
+	cfg  ImageConfig
+}
+
+// NewImageURLBuilder создаёт построитель URL изображений
+func NewImageURLBuilder(shopURL string, cfg ImageConfig) (*ImageURLBuilder, error) {
+	shop, err := url.Parse(strings.TrimSpace(shopURL))
+	if err != nil || shop.Scheme == "" || shop.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidShopURL, shopURL)
+	}
+
+	cdn := strings.TrimRight(strings.TrimSpace(cfg.CDNBaseURL), "/")
+	if cdn != "" {
+		parsed, err := url.Parse(cdn)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("CDN base URL must be absolute: %q", cfg.CDNBaseURL)
+		}
+	} else {
+		cdn = strings.TrimRight(shop.String(), "/")
+	}
+
+	if strings.TrimSpace(cfg.Template) == "" {
+		cfg.Template = DefaultImageTemplate
+	}
+
+	return &ImageURLBuilder{
+		shop: strings.TrimRight(shop.String(), "/"),
+		cdn:  cdn,
+		cfg:  cfg,
+	}, nil
+}
+
+// Build возвращает изображения, отсортированные по position, с уникальными URL
+func (b *ImageURLBuilder) Build(productID string, sources []ImageSource) []entity.Image {
+	sorted := make([]ImageSource, 0, len(sources))
+	for _, src := range sources {
+		if strings.TrimSpace(src.Image) != "" {
+			sorted = append(sorted, src)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+
+	seen := make(map[string]bool, len(sorted))
+	images := make([]entity.Image, 0, len(sorted))
+	for _, src := range sorted {
+		imageURL := b.buildURL(productID, strings.TrimSpace(src.Image))
+		if seen[imageURL] {
+			continue
+		}
+		seen[imageURL] = true
+
+		img := entity.Image{
+			URL:      imageURL,
+			Position: src.Position,
+		}
+		if b.cfg.IncludeMeta {
+			img.Title = strings.TrimSpace(src.Title)
+			img.Description = strings.TrimSpace(src.Description)
+		}
+		images = append(images, img)
+	}
+
+	return images
+}
+
+// buildURL формирует абсолютный URL одного изображения
+func (b *ImageURLBuilder) buildURL(productID, image string) string {
+	// Абсолютные URL из API используются как есть
+	if parsed, err := url.Parse(image); err == nil && parsed.IsAbs() {
+		return parsed.String()
+	}
+
+	replacer := strings.NewReplacer(
+		"{shop}", b.shop,
+		"{cdn}", b.cdn,
+		"{image}", strings.TrimLeft(image, "/"),
+		"{id}", productID,
+	)
+	rendered := replacer.Replace(b.cfg.Template)
+
+	parsed, err := url.Parse(rendered)
+	if err != nil {
+		return rendered
+	}
+	parsed.Path, parsed.RawPath = cleanPath(parsed.EscapedPath())
+	return parsed.String()
+}
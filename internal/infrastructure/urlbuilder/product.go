@@ -0,0 +1,236 @@
+package urlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Strategy определяет способ построения URL товара
+type Strategy string
+
+const (
+	// StrategyCategoryPath - путь из slug'ов категорий и slug товара
+	StrategyCategoryPath Strategy = "category"
+	// StrategyParentURL - канонический parentUrl из page.links и slug товара
+	StrategyParentURL Strategy = "parent"
+	// StrategyTemplate - произвольный шаблон, например {shop}/{category_path}/{slug}.html
+	StrategyTemplate Strategy = "template"
+)
+
+var (
+	ErrUnknownStrategy = errors.New("unknown product URL strategy")
+	ErrEmptyTemplate   = errors.New("product URL template is required for template strategy")
+	ErrInvalidShopURL  = errors.New("shop URL must be absolute")
+	ErrEmptySlug       = errors.New("product slug is empty")
+)
+
+// Config содержит настройки построения URL товаров
+type Config struct {
+	Strategy      Strategy // Стратегия построения URL
+	Template      string   // Шаблон для StrategyTemplate
+	TrailingSlash bool     // Добавлять ли завершающий слеш (кроме шаблонов с расширением)
+}
+
+// ParentLink представляет ссылку товара на родительскую страницу (page.links)
+type ParentLink struct {
+	URL      string // parentUrl
+	ParentID string // ID родительской страницы
+	Position int    // Порядок ссылки
+}
+
+// ProductPage содержит исходные данные для построения URL товара
+type ProductPage struct {
+	ProductID    string       // ID товара
+	CategoryID   string       // ID основной категории
+	Slug         string       // page.url товара
+	CategoryPath []string     // Сегменты пути основной категории от корня
+	Links        []ParentLink // page.links товара
+}
+
+// ProductURLBuilder строит канонические абсолютные URL товаров
+type ProductURLBuilder struct {
+	base *url.URL
+	cfg  Config
+}
+
+// NewProductURLBuilder создаёт построитель URL товаров
+func NewProductURLBuilder(shopURL string, cfg Config) (*ProductURLBuilder, error) {
+	base, err := url.Parse(strings.TrimSpace(shopURL))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidShopURL, shopURL)
+	}
+	base.Path = strings.TrimRight(base.Path, "/")
+	base.RawQuery = ""
+	base.Fragment = ""
+
+	if cfg.Strategy == "" {
+		cfg.Strategy = StrategyCategoryPath
+	}
+	switch cfg.Strategy {
+	case StrategyCategoryPath, StrategyParentURL:
+	case StrategyTemplate:
+		if strings.TrimSpace(cfg.Template) == "" {
+			return nil, ErrEmptyTemplate
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, cfg.Strategy)
+	}
+
+	return &ProductURLBuilder{base: base, cfg: cfg}, nil
+}
+
+// Build возвращает абсолютный URL страницы товара
+func (b *ProductURLBuilder) Build(page ProductPage) (string, error) {
+	slug := strings.Trim(page.Slug, "/")
+	if slug == "" {
+		return "", fmt.Errorf("%w: product %s", ErrEmptySlug, page.ProductID)
+	}
+
+	categoryPath := strings.Join(page.CategoryPath, "/")
+
+	switch b.cfg.Strategy {
+	case StrategyParentURL:
+		// Если ссылок нет, используем путь категорий
+		parent := CanonicalLink(page.Links, categoryPath)
+		if parent == "" {
+			parent = categoryPath
+		}
+		return b.resolve(joinSegments(parent, slug), b.cfg.TrailingSlash), nil
+
+	case StrategyTemplate:
+		parent := CanonicalLink(page.Links, categoryPath)
+		replacer := strings.NewReplacer(
+			"{shop}", b.base.String(),
+			"{category_path}", categoryPath,
+			"{parent_url}", strings.Trim(parent, "/"),
+			"{slug}", slug,
+			"{id}", page.ProductID,
+			"{category_id}", page.CategoryID,
+		)
+		rendered := replacer.Replace(b.cfg.Template)
+		// Завершающий слеш не добавляется к адресам с расширением (.html и т.п.)
+		trailing := b.cfg.TrailingSlash && path.Ext(strings.TrimRight(rendered, "/")) == ""
+		return b.resolve(rendered, trailing), nil
+
+	default:
+		return b.resolve(joinSegments(categoryPath, slug), b.cfg.TrailingSlash), nil
+	}
+}
+
+// CategoryURL возвращает абсолютный URL страницы категории по сегментам пути от корня
+func (b *ProductURLBuilder) CategoryURL(categoryPath []string) string {
+	path := joinSegments(categoryPath...)
+	if path == "" {
+		return ""
+	}
+	return b.resolve(path, b.cfg.TrailingSlash)
+}
+
+// CanonicalLink выбирает канонический parentUrl среди нескольких ссылок:
+// ссылка, совпадающая с путём основной категории, иначе первая по position.
+func CanonicalLink(links []ParentLink, categoryPath string) string {
+	candidates := make([]ParentLink, 0, len(links))
+	for _, link := range links {
+		trimmed := strings.Trim(link.URL, "/")
+		if trimmed == "" {
+			continue
+		}
+		if trimmed == strings.Trim(categoryPath, "/") {
+			return trimmed
+		}
+		link.URL = trimmed
+		candidates = append(candidates, link)
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Position < candidates[j].Position
+	})
+	return candidates[0].URL
+}
+
+// resolve нормализует путь или URL в валидный абсолютный URL магазина
+func (b *ProductURLBuilder) resolve(raw string, trailingSlash bool) string {
+	u := *b.base
+
+	var p string
+	if parsed, err := url.Parse(raw); err == nil && parsed.IsAbs() {
+		u = *parsed
+		p = parsed.EscapedPath()
+	} else {
+		// Относительный путь (в т.ч. с хостом магазина без схемы) добавляется к базовому URL
+		p = b.base.EscapedPath() + "/" + strings.TrimLeft(b.trimHost(raw), "/")
+	}
+
+	u.Path, u.RawPath = cleanPath(p)
+	if trailingSlash && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	return u.String()
+}
+
+// joinSegments объединяет непустые сегменты пути
+func joinSegments(parts ...string) string {
+	segments := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.Trim(p, "/"); p != "" {
+			segments = append(segments, p)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// trimHost убирает хост магазина в начале пути без схемы ("shop.by/catalog/...").
+// Путь, лишь начинающийся с тех же символов ("shop.bycatalog/..."), не изменяется
+func (b *ProductURLBuilder) trimHost(raw string) string {
+	host := b.base.Host
+	if host == "" || len(raw) < len(host) || !strings.EqualFold(raw[:len(host)], host) {
+		return raw
+	}
+	if rest := raw[len(host):]; rest == "" || strings.HasPrefix(rest, "/") {
+		return rest
+	}
+	return raw
+}
+
+// cleanPath убирает повторяющиеся слеши и декодирует уже экранированные сегменты,
+// чтобы при сериализации они были экранированы ровно один раз. Экранированный
+// слеш (%2F) остаётся частью сегмента: для таких путей кроме декодированного
+// возвращается и экранированный вид (url.URL.RawPath), иначе он пустой
+func cleanPath(p string) (string, string) {
+	parts := strings.Split(p, "/")
+	decoded := make([]string, 0, len(parts))
+	escaped := make([]string, 0, len(parts))
+	encodedSlash := false
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		if strings.Contains(strings.ToUpper(part), "%2F") {
+			encodedSlash = true
+		}
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			part = unescaped
+		}
+		decoded = append(decoded, part)
+		escaped = append(escaped, url.PathEscape(part))
+	}
+
+	path := "/" + strings.Join(decoded, "/")
+	if !encodedSlash {
+		return path, ""
+	}
+	return path, "/" + strings.Join(escaped, "/")
+}
@@ -0,0 +1,72 @@
+package urlbuilder
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// TrackingDecorator добавляет UTM и другие трекинг-параметры к URL товаров
+type TrackingDecorator struct{}
+
+// NewTrackingDecorator создаёт декоратор трекинг-параметров
+func NewTrackingDecorator() *TrackingDecorator {
+	return &TrackingDecorator{}
+}
+
+// Decorate добавляет параметры к URL. Значения могут содержать плейсхолдеры
+// вида {offer_id}, которые заменяются из vars. Одноимённые параметры в исходном
+// URL заменяются, остальная строка запроса и фрагмент сохраняются как есть.
+func (d *TrackingDecorator) Decorate(rawURL string, params map[string]string, vars map[string]string) (string, error) {
+	if len(params) == 0 || rawURL == "" {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL %q: %w", rawURL, err)
+	}
+
+	replacements := make([]string, 0, len(vars)*2)
+	for name, value := range vars {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	// Удаляем из исходного запроса параметры, которые будут переопределены
+	kept := make([]string, 0)
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key := pair
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			key = pair[:i]
+		}
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if _, overridden := params[key]; overridden {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := replacer.Replace(params[key])
+		if value == "" {
+			continue
+		}
+		kept = append(kept, url.QueryEscape(key)+"="+url.QueryEscape(value))
+	}
+
+	u.RawQuery = strings.Join(kept, "&")
+	return u.String(), nil
+}
@@ -0,0 +1,130 @@
+package yml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// KaspiCurrency — Kaspi принимает прайс-листы только в тенге
+const KaspiCurrency = "KZT"
+
+var (
+	ErrMissingMerchantID = errors.New("kaspi merchant id is required")
+	ErrMissingStoreIDs   = errors.New("kaspi store ids are required")
+)
+
+// Kaspi — прайс-лист Kaspi.kz: собственный корень kaspi_catalog,
+// целые цены в тенге и наличие по складам продавца
+var Kaspi = &Profile{
+	Name: "kaspi",
+	Offer: func(offer *Offer, prod *entity.Product) {
+		plainName(offer, prod)
+		offer.Price = prod.Price.Format(0) // цены Kaspi — целые
+		if prod.Stock != nil {
+			offer.StockQuantity = strconv.Itoa(*prod.Stock)
+		}
+	},
+	Validate: func(offer *Offer) error {
+		if offer.CurrencyID != KaspiCurrency {
+			return fmt.Errorf("kaspi requires %s prices, offer currency is %s", KaspiCurrency, offer.CurrencyID)
+		}
+		return nil
+	},
+	Document: buildKaspiCatalog,
+}
+
+// KaspiCatalog представляет корневой элемент прайс-листа Kaspi
+type KaspiCatalog struct {
+	XMLName        xml.Name    `xml:"kaspi_catalog"`
+	Date           string      `xml:"date,attr"`
+	Xmlns          string      `xml:"xmlns,attr"`
+	XmlnsXSI       string      `xml:"xmlns:xsi,attr"`
+	SchemaLocation string      `xml:"xsi:schemaLocation,attr"`
+	Company        string      `xml:"company"`
+	MerchantID     string      `xml:"merchantid"`
+	Offers         KaspiOffers `xml:"offers"`
+}
+
+// KaspiOffers представляет список предложений Kaspi
+type KaspiOffers struct {
+	Offer []KaspiOffer `xml:"offer"`
+}
+
+// KaspiOffer представляет предложение Kaspi; sku — артикул продавца
+type KaspiOffer struct {
+	SKU            string              `xml:"sku,attr"`
+	Model          string              `xml:"model"`
+	Brand          string              `xml:"brand,omitempty"`
+	Availabilities KaspiAvailabilities `xml:"availabilities"`
+	Price          string              `xml:"price"`
+}
+
+// KaspiAvailabilities представляет наличие по складам
+type KaspiAvailabilities struct {
+	Availability []KaspiAvailability `xml:"availability"`
+}
+
+// KaspiAvailability представляет наличие на складе продавца
+type KaspiAvailability struct {
+	Available  string `xml:"available,attr"` // yes или no
+	StoreID    string `xml:"storeId,attr"`
+	StockCount string `xml:"stockCount,attr,omitempty"`
+}
+
+// buildKaspiCatalog преобразует YML каталог в прайс-лист Kaspi
+func buildKaspiCatalog(catalog YMLCatalog, opts Options) (interface{}, error) {
+	if opts.MerchantID == "" {
+		return nil, ErrMissingMerchantID
+	}
+	if len(opts.StoreIDs) == 0 {
+		return nil, ErrMissingStoreIDs
+	}
+
+	result := KaspiCatalog{
+		Date:           catalog.Date,
+		Xmlns:          "kaspiShopping",
+		XmlnsXSI:       "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "kaspiShopping http://kaspi.kz/kaspishopping.xsd",
+		Company:        catalog.Shop.Company,
+		MerchantID:     opts.MerchantID,
+	}
+
+	result.Offers.Offer = make([]KaspiOffer, 0, len(catalog.Shop.Offers.Offer))
+	for _, offer := range catalog.Shop.Offers.Offer {
+		sku := offer.ID
+		if offer.VendorCode != "" {
+			sku = offer.VendorCode
+		}
+		available := "no"
+		if offer.Available == "true" {
+			available = "yes"
+		}
+
+		kaspiOffer := KaspiOffer{
+			SKU:   sku,
+			Model: offer.Name,
+			Brand: offer.Vendor,
+			Price: offer.Price,
+		}
+		// Остаток товара общий, а не складской: при нескольких складах Kaspi сложил бы
+		// его по каждому из них, поэтому stockCount выводится только для единственного склада
+		stockCount := ""
+		if len(opts.StoreIDs) == 1 {
+			stockCount = offer.StockQuantity
+		}
+		for _, storeID := range opts.StoreIDs {
+			kaspiOffer.Availabilities.Availability = append(kaspiOffer.Availabilities.Availability, KaspiAvailability{
+				Available:  available,
+				StoreID:    storeID,
+				StockCount: stockCount,
+			})
+		}
+
+		result.Offers.Offer = append(result.Offers.Offer, kaspiOffer)
+	}
+	return result, nil
+}
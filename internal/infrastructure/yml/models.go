@@ -16,7 +16,12 @@ type Shop struct {
 	URL        string     `xml:"url"`
 	Currencies Currencies `xml:"currencies"`
 	Categories Categories `xml:"categories"`
-	Offers     Offers     `xml:"offers"`
+
+	DeliveryOptions *DeliveryOptions `xml:"delivery-options,omitempty"`
+	PickupOptions   *DeliveryOptions `xml:"pickup-options,omitempty"`
+
+	Offers Offers  `xml:"offers"`
+	Promos *Promos `xml:"promos,omitempty"`
 }
 
 // Currencies представляет список валют
@@ -42,6 +47,18 @@ type Category struct {
 	Name     string  `xml:",chardata"`
 }
 
+// DeliveryOptions представляет блок delivery-options или pickup-options
+type DeliveryOptions struct {
+	Option []DeliveryOption `xml:"option"`
+}
+
+// DeliveryOption представляет вариант доставки или самовывоза
+type DeliveryOption struct {
+	Cost        string `xml:"cost,attr"`
+	Days        string `xml:"days,attr"`
+	OrderBefore string `xml:"order-before,attr,omitempty"`
+}
+
 // Offers представляет список предложений
 type Offers struct {
 	Offer []Offer `xml:"offer"`
@@ -49,15 +66,85 @@ type Offers struct {
 
 // Offer представляет товарное предложение
 type Offer struct {
-	ID          string   `xml:"id,attr"`
-	Available   string   `xml:"available,attr"`
-	URL         string   `xml:"url,omitempty"`
-	Price       float64  `xml:"price"`
-	CurrencyID  string   `xml:"currencyId"`
-	CategoryID  string   `xml:"categoryId"`
-	Picture     []string `xml:"picture,omitempty"`
-	Name        string   `xml:"name"`
-	Vendor      string   `xml:"vendor,omitempty"`
-	Barcode     string   `xml:"barcode,omitempty"`
-	Description string   `xml:"description,omitempty"`
+	ID             string   `xml:"id,attr"`
+	Type           string   `xml:"type,attr,omitempty"` // vendor.model или пусто для упрощённого типа
+	Available      string   `xml:"available,attr"`
+	URL            string   `xml:"url,omitempty"`
+	Price          string   `xml:"price"` // фиксированное число знаков, например 19.99
+	CurrencyID     string   `xml:"currencyId"`
+	CategoryID     string   `xml:"categoryId"`
+	MarketCategory string   `xml:"market_category,omitempty"` // подсказка категории Яндекс.Маркета
+	Picture        []string `xml:"picture,omitempty"`
+
+	Store           string           `xml:"store,omitempty"`
+	Pickup          string           `xml:"pickup,omitempty"`
+	Delivery        string           `xml:"delivery,omitempty"`
+	DeliveryOptions *DeliveryOptions `xml:"delivery-options,omitempty"`
+
+	StockQuantity   string `xml:"stock_quantity,omitempty"`    // Rozetka
+	QuantityInStock string `xml:"quantity_in_stock,omitempty"` // Prom.ua
+
+	Name          string  `xml:"name,omitempty"`
+	NameUA        string  `xml:"name_ua,omitempty"`
+	TypePrefix    string  `xml:"typePrefix,omitempty"`
+	Vendor        string  `xml:"vendor,omitempty"`
+	VendorCode    string  `xml:"vendorCode,omitempty"`
+	Model         string  `xml:"model,omitempty"`
+	Barcode       string  `xml:"barcode,omitempty"`
+	Description   string  `xml:"description,omitempty"`
+	DescriptionUA string  `xml:"description_ua,omitempty"`
+	Param         []Param `xml:"param,omitempty"`
+}
+
+// Param представляет характеристику предложения
+type Param struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Promos представляет список акций
+type Promos struct {
+	Promo []Promo `xml:"promo"`
+}
+
+// Promo представляет акцию типа «промокод»
+type Promo struct {
+	ID          string         `xml:"id,attr"`
+	Type        string         `xml:"type,attr"`
+	StartDate   string         `xml:"start-date,omitempty"`
+	EndDate     string         `xml:"end-date,omitempty"`
+	Description string         `xml:"description,omitempty"`
+	PromoCode   string         `xml:"promo-code,omitempty"`
+	Discount    *PromoDiscount `xml:"discount,omitempty"`
+	Purchase    PromoPurchase  `xml:"purchase"`
+	PromoGifts  *PromoGifts    `xml:"promo-gifts,omitempty"`
+}
+
+// PromoDiscount представляет размер скидки акции
+type PromoDiscount struct {
+	Unit     string `xml:"unit,attr"`
+	Currency string `xml:"currency,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// PromoPurchase представляет товары и категории, на которые действует акция
+type PromoPurchase struct {
+	RequiredQuantity int            `xml:"required-quantity,omitempty"`
+	Product          []PromoProduct `xml:"product"`
+}
+
+// PromoGifts представляет подарки акции
+type PromoGifts struct {
+	PromoGift []PromoGift `xml:"promo-gift"`
+}
+
+// PromoGift ссылается на предложение, выдаваемое в подарок
+type PromoGift struct {
+	OfferID string `xml:"offer-id,attr"`
+}
+
+// PromoProduct ссылается на предложение или категорию
+type PromoProduct struct {
+	OfferID    string `xml:"offer-id,attr,omitempty"`
+	CategoryID string `xml:"category-id,attr,omitempty"`
 }
@@ -0,0 +1,159 @@
+package yml
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// Атрибуты товара из маппинга полей, используемые диалектами
+const (
+	AttributeNameUA        = "name_ua"        // Название на украинском
+	AttributeDescriptionUA = "description_ua" // Описание на украинском
+	AttributeParamPrefix   = "param:"         // Префикс характеристик: "param:Цвет" → <param name="Цвет">
+)
+
+// Profile описывает диалект YML площадки: набор элементов, их наименование
+// и правила проверки. Базовое предложение строит buildOffer, профиль только
+// дорабатывает его хуками, поэтому новый диалект не требует копии buildCatalog
+type Profile struct {
+	Name    string // Имя профиля для логов и ошибок
+	Doctype bool   // Выводить <!DOCTYPE yml_catalog SYSTEM "shops.dtd">
+
+	// Available задаёт атрибут available предложения (nil — "true"/"false" по наличию)
+	Available func(prod *entity.Product) string
+	// Offer дорабатывает готовое предложение: добавляет, убирает или переименовывает элементы
+	Offer func(offer *Offer, prod *entity.Product)
+	// RequiredParams — характеристики <param>, без которых предложение не выгружается
+	RequiredParams []string
+	// Validate проверяет предложение по правилам площадки
+	Validate func(offer *Offer) error
+	// Document заменяет yml_catalog собственным корневым документом
+	Document func(catalog YMLCatalog, opts Options) (interface{}, error)
+}
+
+// Yandex — исходный YML Яндекс.Маркета
+var Yandex = &Profile{
+	Name:    "yandex",
+	Doctype: true,
+}
+
+// Rozetka — YML Rozetka: stock_quantity, name_ua/description_ua,
+// обязательные бренд и фото, характеристики из атрибутов "param:*"
+var Rozetka = &Profile{
+	Name: "rozetka",
+	Offer: func(offer *Offer, prod *entity.Product) {
+		plainName(offer, prod)
+		localize(offer, prod)
+		offer.Param = append(offer.Param, attributeParams(prod)...)
+		if prod.Stock != nil {
+			offer.StockQuantity = strconv.Itoa(*prod.Stock)
+		}
+	},
+	Validate: func(offer *Offer) error {
+		switch {
+		case offer.Vendor == "":
+			return errors.New("vendor is required")
+		case len(offer.Picture) == 0:
+			return errors.New("at least one picture is required")
+		}
+		return nil
+	},
+}
+
+// PromUA — YML Prom.ua: available="true" — в наличии, "false" — под заказ,
+// пустой — нет в наличии; остаток в quantity_in_stock
+var PromUA = &Profile{
+	Name: "prom",
+	Available: func(prod *entity.Product) string {
+		switch {
+		case prod.Available:
+			return "true"
+		case prod.DeliveryDays != nil:
+			return "false"
+		default:
+			return ""
+		}
+	},
+	Offer: func(offer *Offer, prod *entity.Product) {
+		plainName(offer, prod)
+		localize(offer, prod)
+		offer.Param = append(offer.Param, attributeParams(prod)...)
+		if prod.Stock != nil {
+			offer.QuantityInStock = strconv.Itoa(*prod.Stock)
+		}
+	},
+}
+
+// available вычисляет атрибут available по правилам профиля
+func (p *Profile) available(prod *entity.Product) string {
+	if p.Available != nil {
+		return p.Available(prod)
+	}
+	return strconv.FormatBool(prod.Available)
+}
+
+// validate проверяет обязательные характеристики профиля и фида, затем правила площадки
+func (p *Profile) validate(offer *Offer, extra []string) error {
+	for _, name := range append(append([]string(nil), p.RequiredParams...), extra...) {
+		if !hasParam(offer, name) {
+			return fmt.Errorf("required param %q is missing", name)
+		}
+	}
+	if p.Validate != nil {
+		return p.Validate(offer)
+	}
+	return nil
+}
+
+// hasParam проверяет наличие непустой характеристики
+func hasParam(offer *Offer, name string) bool {
+	for _, param := range offer.Param {
+		if strings.EqualFold(param.Name, name) && param.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// plainName возвращает name для площадок без поддержки vendor.model
+func plainName(offer *Offer, prod *entity.Product) {
+	if offer.Type != string(entity.OfferTypeVendorModel) {
+		return
+	}
+	offer.Type = ""
+	offer.TypePrefix = ""
+	offer.Model = ""
+	offer.Name = prod.Name
+}
+
+// localize заполняет украинские название и описание из атрибутов товара
+func localize(offer *Offer, prod *entity.Product) {
+	offer.NameUA = prod.Attribute(AttributeNameUA)
+	offer.DescriptionUA = prod.Attribute(AttributeDescriptionUA)
+}
+
+// attributeParams превращает атрибуты "param:Название" в характеристики;
+// порядок по имени, чтобы выгрузка была стабильной
+func attributeParams(prod *entity.Product) []Param {
+	var names []string
+	for key, value := range prod.Attributes {
+		if strings.HasPrefix(key, AttributeParamPrefix) && value != "" {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	params := make([]Param, 0, len(names))
+	for _, key := range names {
+		params = append(params, Param{
+			Name:  strings.TrimPrefix(key, AttributeParamPrefix),
+			Value: prod.Attributes[key],
+		})
+	}
+	return params
+}
@@ -1,12 +1,13 @@
 package yml
 
 import (
-	"encoding/xml"
 	"fmt"
-	"os"
+	"strconv"
 	"time"
 
 	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/infrastructure/categorymap"
+	"beseller-yml-exporter/internal/infrastructure/output"
 )
 
 // Logger интерфейс для логирования
@@ -17,87 +18,109 @@ type Logger interface {
 	Error(msg string, args ...interface{})
 }
 
+// Options содержит настройки YML writer
+type Options struct {
+	MaxPictures int                // Максимум <picture> в offer (0 — без ограничения)
+	Profile     *Profile           // Диалект площадки (nil — Яндекс.Маркет)
+	Categories  *categorymap.Table // Соответствие категорий yandex_market_category (market_category)
+
+	RequiredParams []string // Дополнительные обязательные <param> сверх требований профиля
+	MerchantID     string   // ID продавца (Kaspi)
+	StoreIDs       []string // Склады/точки самовывоза для наличия (Kaspi)
+}
+
 // Writer реализует запись каталога в YML формат
 type Writer struct {
-	logger Logger
+	logger  Logger
+	opts    Options
+	profile *Profile
 }
 
 // NewWriter создаёт новый YML writer
-func NewWriter(logger Logger) *Writer {
+func NewWriter(logger Logger, opts Options) *Writer {
+	profile := opts.Profile
+	if profile == nil {
+		profile = Yandex
+	}
+	if opts.Categories == nil {
+		opts.Categories = categorymap.NewTable(nil)
+	}
 	return &Writer{
-		logger: logger,
+		logger:  logger,
+		opts:    opts,
+		profile: profile,
 	}
 }
 
-// Write записывает каталог в YML файл
-func (w *Writer) Write(
-	outputPath string,
-	shopName string,
-	shopCompany string,
-	shopURL string,
-	currency string,
-	categories []entity.Category,
-	products []entity.Product,
-) error {
-	catalog := w.buildCatalog(shopName, shopCompany, shopURL, currency, categories, products)
-
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	// Запись XML заголовка
-	if _, err := file.WriteString(xml.Header); err != nil {
-		return fmt.Errorf("failed to write XML header: %w", err)
+// Accept проверяет предложение товара по правилам профиля
+func (w *Writer) Accept(prod *entity.Product, tree *entity.CategoryTree) (bool, string) {
+	offer := w.buildOffer(prod, tree, nil)
+	if err := w.profile.validate(&offer, w.opts.RequiredParams); err != nil {
+		return false, err.Error()
 	}
+	return true, ""
+}
 
-	// Запись DOCTYPE
-	if _, err := file.WriteString(`<!DOCTYPE yml_catalog SYSTEM "shops.dtd">` + "\n"); err != nil {
-		return fmt.Errorf("failed to write DOCTYPE: %w", err)
+// UnmappedCategories возвращает категории товаров без market_category,
+// если подсказки Яндекс.Маркета заданы в таблице соответствия
+func (w *Writer) UnmappedCategories(tree *entity.CategoryTree, products []entity.Product) []string {
+	if !w.opts.Categories.Has(categorymap.KeyYandexMarketCategory) {
+		return nil
 	}
+	return w.opts.Categories.Unmapped(tree, products, categorymap.KeyYandexMarketCategory)
+}
 
-	// Кодирование XML
-	encoder := xml.NewEncoder(file)
-	encoder.Indent("", "  ")
+// Write записывает каталог в YML файл
+func (w *Writer) Write(outputPath string, source entity.Catalog) error {
+	catalog := w.buildCatalog(source)
 
-	if err := encoder.Encode(catalog); err != nil {
-		return fmt.Errorf("failed to encode XML: %w", err)
+	// Профиль может заменить yml_catalog собственным документом
+	var document interface{} = catalog
+	if w.profile.Document != nil {
+		custom, err := w.profile.Document(catalog, w.opts)
+		if err != nil {
+			return fmt.Errorf("%s profile: %w", w.profile.Name, err)
+		}
+		document = custom
 	}
 
-	if err := encoder.Flush(); err != nil {
-		return fmt.Errorf("failed to flush encoder: %w", err)
+	// DOCTYPE выводится сразу после XML заголовка
+	doctype := ""
+	if w.profile.Doctype {
+		doctype = `<!DOCTYPE yml_catalog SYSTEM "shops.dtd">` + "\n"
 	}
-
-	return nil
+	return output.WriteXML(outputPath, doctype, document)
 }
 
 // buildCatalog создаёт структуру YML каталога
-func (w *Writer) buildCatalog(
-	shopName string,
-	shopCompany string,
-	shopURL string,
-	currency string,
-	categories []entity.Category,
-	products []entity.Product,
-) YMLCatalog {
+func (w *Writer) buildCatalog(source entity.Catalog) YMLCatalog {
+	categories := source.Categories
+	products := source.Products
+
 	catalog := YMLCatalog{
 		Date: time.Now().Format("2006-01-02 15:04"),
 		Shop: Shop{
-			Name:    shopName,
-			Company: shopCompany,
-			URL:     shopURL,
+			Name:    source.Shop.Name,
+			Company: source.Shop.Company,
+			URL:     source.Shop.URL,
 		},
 	}
 
 	// Валюты
 	catalog.Shop.Currencies.Currency = []Currency{
 		{
-			ID:   currency,
+			ID:   source.Shop.Currency,
 			Rate: "1",
 		},
 	}
 
+	// Условия доставки магазина
+	terms := source.Shop.Delivery
+	if terms != nil {
+		catalog.Shop.DeliveryOptions = buildDeliveryOptions(terms.DeliveryOptions)
+		catalog.Shop.PickupOptions = buildDeliveryOptions(terms.PickupOptions)
+	}
+
 	// Категории
 	catalog.Shop.Categories.Category = make([]Category, 0, len(categories))
 	for _, cat := range categories {
@@ -110,39 +133,154 @@ func (w *Writer) buildCatalog(
 	}
 
 	// Товары
+	tree := entity.NewCategoryTree(categories)
 	catalog.Shop.Offers.Offer = make([]Offer, 0, len(products))
-	for _, prod := range products {
-		available := "true"
-		if !prod.Available {
-			available = "false"
-		}
+	for i := range products {
+		catalog.Shop.Offers.Offer = append(catalog.Shop.Offers.Offer, w.buildOffer(&products[i], tree, terms))
+	}
+
+	// Акции по промокодам
+	catalog.Shop.Promos = buildPromos(source.Promos)
+
+	return catalog
+}
+
+// buildOffer создаёт предложение товара и дорабатывает его хуком профиля
+func (w *Writer) buildOffer(prod *entity.Product, tree *entity.CategoryTree, terms *entity.DeliveryTerms) Offer {
+	offer := Offer{
+		ID:         prod.ID,
+		Available:  w.profile.available(prod),
+		URL:        prod.URL,
+		Price:      prod.Price.String(),
+		CurrencyID: prod.Price.Currency(),
+		CategoryID: prod.CategoryID,
+		Name:       prod.Name,
+	}
+	offer.MarketCategory, _ = w.opts.Categories.Resolve(tree, prod.CategoryID, categorymap.KeyYandexMarketCategory)
+
+	// vendor.model: вместо name выводятся typePrefix, vendor и model
+	if prod.OfferType == entity.OfferTypeVendorModel {
+		offer.Type = string(entity.OfferTypeVendorModel)
+		offer.Name = ""
+		offer.TypePrefix = prod.TypePrefix
+		offer.Model = prod.Model
+	}
 
-		offer := Offer{
-			ID:         prod.ID,
-			Available:  available,
-			URL:        prod.URL,
-			Price:      prod.Price,
-			CurrencyID: prod.Currency,
-			CategoryID: prod.CategoryID,
-			Name:       prod.Name,
+	// Картинки
+	offer.Picture = prod.GetImageURLsLimit(w.opts.MaxPictures)
+
+	// Доставка: флаги магазина и индивидуальные сроки товара
+	if terms != nil {
+		offer.Store = strconv.FormatBool(terms.Store)
+		offer.Pickup = strconv.FormatBool(terms.Pickup)
+		offer.Delivery = strconv.FormatBool(terms.Delivery)
+	}
+	offer.DeliveryOptions = buildDeliveryOptions(prod.DeliveryOptions)
+
+	// Опциональные поля
+	if prod.Vendor != nil && *prod.Vendor != "" {
+		offer.Vendor = *prod.Vendor
+	}
+	if prod.SKU != nil && *prod.SKU != "" {
+		offer.VendorCode = *prod.SKU
+	}
+	if prod.Barcode != nil && *prod.Barcode != "" {
+		offer.Barcode = *prod.Barcode
+	}
+	if prod.Description != nil && *prod.Description != "" {
+		offer.Description = *prod.Description
+	}
+	if prod.Rating != nil {
+		offer.Param = append(offer.Param,
+			Param{Name: "Рейтинг", Value: strconv.FormatFloat(prod.Rating.Value, 'f', 1, 64)},
+			Param{Name: "Количество отзывов", Value: strconv.Itoa(prod.Rating.Count)},
+		)
+	}
+	for _, param := range prod.Params {
+		offer.Param = append(offer.Param, Param{Name: param.Name, Value: param.Value})
+	}
+
+	if w.profile.Offer != nil {
+		w.profile.Offer(&offer, prod)
+	}
+	return offer
+}
+
+// buildDeliveryOptions преобразует варианты доставки; стоимость в YML — целое число
+func buildDeliveryOptions(options []entity.DeliveryOption) *DeliveryOptions {
+	if len(options) == 0 {
+		return nil
+	}
+
+	result := &DeliveryOptions{Option: make([]DeliveryOption, 0, len(options))}
+	for _, opt := range options {
+		option := DeliveryOption{
+			Cost: opt.Cost.Format(0),
+			Days: opt.Days,
+		}
+		if opt.OrderBefore != nil {
+			option.OrderBefore = strconv.Itoa(*opt.OrderBefore)
 		}
+		result.Option = append(result.Option, option)
+	}
+	return result
+}
 
-		// Картинки
-		offer.Picture = prod.GetImageURLs()
+// promoDateLayout — формат дат акций в YML
+const promoDateLayout = "2006-01-02 15:04:05"
 
-		// Опциональные поля
-		if prod.Vendor != nil && *prod.Vendor != "" {
-			offer.Vendor = *prod.Vendor
+// buildPromos преобразует акции (промокоды и подарки) в блок promos
+func buildPromos(promos []entity.Promo) *Promos {
+	if len(promos) == 0 {
+		return nil
+	}
+
+	result := &Promos{Promo: make([]Promo, 0, len(promos))}
+	for _, p := range promos {
+		promo := Promo{
+			ID:          p.ID,
+			Type:        string(p.Type),
+			Description: p.Description,
 		}
-		if prod.Barcode != nil && *prod.Barcode != "" {
-			offer.Barcode = *prod.Barcode
+		if p.StartDate != nil {
+			promo.StartDate = p.StartDate.Format(promoDateLayout)
 		}
-		if prod.Description != nil && *prod.Description != "" {
-			offer.Description = *prod.Description
+		if p.EndDate != nil {
+			promo.EndDate = p.EndDate.Format(promoDateLayout)
 		}
 
-		catalog.Shop.Offers.Offer = append(catalog.Shop.Offers.Offer, offer)
-	}
+		switch p.Type {
+		case entity.PromoTypeGift:
+			// Подарок при покупке: required-quantity товаров и список подарков
+			promo.Purchase.RequiredQuantity = p.RequiredQuantity
+			promo.PromoGifts = &PromoGifts{}
+			for _, offerID := range p.GiftOfferIDs {
+				promo.PromoGifts.PromoGift = append(promo.PromoGifts.PromoGift, PromoGift{OfferID: offerID})
+			}
+		default:
+			promo.PromoCode = p.Code
+			if p.Unit == entity.DiscountUnitPercent {
+				promo.Discount = &PromoDiscount{
+					Unit:  "percent",
+					Value: strconv.FormatFloat(p.Percent, 'f', -1, 64),
+				}
+			} else {
+				promo.Discount = &PromoDiscount{
+					Unit:     "currency",
+					Currency: p.Amount.Currency(),
+					Value:    p.Amount.String(),
+				}
+			}
+		}
 
-	return catalog
+		for _, offerID := range p.OfferIDs {
+			promo.Purchase.Product = append(promo.Purchase.Product, PromoProduct{OfferID: offerID})
+		}
+		for _, categoryID := range p.CategoryIDs {
+			promo.Purchase.Product = append(promo.Purchase.Product, PromoProduct{CategoryID: categoryID})
+		}
+
+		result.Promo = append(result.Promo, promo)
+	}
+	return result
 }
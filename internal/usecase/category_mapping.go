@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"strings"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// CategoryMappedWriter — writer, формату которого нужно соответствие
+// категорий магазина категориям площадки
+type CategoryMappedWriter interface {
+	CatalogWriter
+	// UnmappedCategories возвращает категории товаров, для которых соответствие не задано
+	UnmappedCategories(tree *entity.CategoryTree, products []entity.Product) []string
+}
+
+// unmappedCategories собирает для отчёта категории фида без соответствия и число их товаров
+func unmappedCategories(writer CatalogWriter, tree *entity.CategoryTree, products []entity.Product) []dto.UnmappedCategory {
+	mapped, ok := writer.(CategoryMappedWriter)
+	if !ok {
+		return nil
+	}
+
+	ids := mapped.UnmappedCategories(tree, products)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int, len(ids))
+	for i := range products {
+		counts[products[i].CategoryID]++
+	}
+
+	result := make([]dto.UnmappedCategory, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, dto.UnmappedCategory{
+			CategoryID: id,
+			Path:       strings.Join(tree.PathNames(id), " > "),
+			Products:   counts[id],
+		})
+	}
+	return result
+}
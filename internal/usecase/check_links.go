@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/domain/repository"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// LinkChecker определяет интерфейс проверки доступности ссылок
+type LinkChecker interface {
+	Check(ctx context.Context, targets []dto.LinkTarget) ([]dto.LinkResult, error)
+	WriteReport(path string, report dto.LinkCheckReport) error
+}
+
+// CheckLinksUseCase реализует сценарий проверки URL и изображений товаров
+type CheckLinksUseCase struct {
+	catalogRepo repository.CatalogRepository
+	checker     LinkChecker
+	logger      Logger
+}
+
+// NewCheckLinksUseCase создаёт новый экземпляр use case
+func NewCheckLinksUseCase(
+	catalogRepo repository.CatalogRepository,
+	checker LinkChecker,
+	logger Logger,
+) *CheckLinksUseCase {
+	return &CheckLinksUseCase{
+		catalogRepo: catalogRepo,
+		checker:     checker,
+		logger:      logger,
+	}
+}
+
+// Execute проверяет ссылки товаров с указанным статусом и сохраняет отчёт
+func (uc *CheckLinksUseCase) Execute(ctx context.Context, req dto.CheckLinksRequest) error {
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	uc.logger.Info(fmt.Sprintf("Fetching products with statusId=%d...", req.StatusID))
+	products, err := uc.catalogRepo.GetProductsByStatus(ctx, req.StatusID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch products: %w", err)
+	}
+
+	selected := make([]entity.Product, 0, len(products))
+	for _, prod := range products {
+		if prod.StatusID == req.StatusID {
+			selected = append(selected, prod)
+		}
+	}
+
+	_, report, err := checkProductLinks(ctx, uc.checker, uc.logger, selected, dto.LinkCheckFlag)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.checker.WriteReport(req.ReportPath, report); err != nil {
+		return fmt.Errorf("failed to write link report: %w", err)
+	}
+
+	uc.logger.Info(fmt.Sprintf("Link report created: %s (checked=%d, broken=%d, products=%d)",
+		req.ReportPath, report.Checked, report.Broken, len(report.Products)))
+	return nil
+}
+
+// checkProductLinks проверяет URL и изображения товаров.
+// В режиме exclude товары с недоступной страницей или без единого доступного
+// изображения исключаются, недоступные изображения удаляются из остальных.
+func checkProductLinks(
+	ctx context.Context,
+	checker LinkChecker,
+	logger Logger,
+	products []entity.Product,
+	mode dto.LinkCheckMode,
+) ([]entity.Product, dto.LinkCheckReport, error) {
+	report := dto.LinkCheckReport{
+		GeneratedAt: time.Now().UTC(),
+		Mode:        mode,
+		Products:    []dto.LinkProductIssue{},
+	}
+
+	targets := make([]dto.LinkTarget, 0, len(products)*2)
+	for _, prod := range products {
+		targets = append(targets, dto.LinkTarget{URL: prod.URL, Kind: dto.LinkKindPage, ProductID: prod.ID})
+		for _, imageURL := range prod.GetImageURLs() {
+			targets = append(targets, dto.LinkTarget{URL: imageURL, Kind: dto.LinkKindImage, ProductID: prod.ID})
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Checking %d links...", len(targets)))
+	results, err := checker.Check(ctx, targets)
+	if err != nil {
+		return nil, report, fmt.Errorf("failed to check links: %w", err)
+	}
+
+	byURL := make(map[string]dto.LinkResult, len(results))
+	for _, res := range results {
+		byURL[res.URL] = res
+		report.Checked++
+		if !res.OK {
+			report.Broken++
+		}
+		if res.Cached {
+			report.FromCache++
+		}
+	}
+
+	kept := make([]entity.Product, 0, len(products))
+	for _, prod := range products {
+		issue := dto.LinkProductIssue{ProductID: prod.ID}
+		pageBroken := false
+
+		if res, ok := byURL[prod.URL]; ok && !res.OK {
+			issue.Broken = append(issue.Broken, res)
+			pageBroken = true
+		}
+
+		images := make([]entity.Image, 0, len(prod.Images))
+		for _, img := range prod.Images {
+			if res, ok := byURL[img.URL]; ok && !res.OK {
+				issue.Broken = append(issue.Broken, res)
+				continue
+			}
+			images = append(images, img)
+		}
+
+		if len(issue.Broken) > 0 {
+			exclude := mode == dto.LinkCheckExclude && (pageBroken || (prod.HasImages() && len(images) == 0))
+			issue.Excluded = exclude
+			report.Products = append(report.Products, issue)
+			logger.Warn(fmt.Sprintf("Product %s has %d broken links (excluded=%t)", prod.ID, len(issue.Broken), exclude))
+			if exclude {
+				continue
+			}
+		}
+
+		if mode == dto.LinkCheckExclude {
+			prod.Images = images
+		}
+		kept = append(kept, prod)
+	}
+
+	return kept, report, nil
+}
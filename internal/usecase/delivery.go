@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// buildDeliveryTerms формирует условия доставки магазина из настроенных ступеней
+// и способов доставки API: варианты, привязанные к неактивному способу, пропускаются.
+func (uc *ExportCatalogUseCase) buildDeliveryTerms(
+	cfg dto.DeliveryConfig,
+	currency string,
+	types []entity.DeliveryType,
+) (*entity.DeliveryTerms, error) {
+	byID := make(map[string]entity.DeliveryType, len(types))
+	for _, dt := range types {
+		byID[dt.ID] = dt
+	}
+
+	convert := func(options []dto.DeliveryOptionConfig) ([]entity.DeliveryOption, error) {
+		result := make([]entity.DeliveryOption, 0, len(options))
+		for _, opt := range options {
+			if opt.DeliveryTypeID != 0 {
+				dt, ok := byID[strconv.Itoa(opt.DeliveryTypeID)]
+				if !ok || !dt.Active {
+					uc.logger.Warn(fmt.Sprintf("Skipping delivery option: delivery type %d is missing or inactive", opt.DeliveryTypeID))
+					continue
+				}
+			}
+			cost, err := entity.NewMoney(opt.Cost, currency)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, entity.DeliveryOption{
+				Cost:        cost,
+				Days:        opt.Days,
+				OrderBefore: opt.OrderBefore,
+			})
+		}
+		return result, nil
+	}
+
+	deliveryOptions, err := convert(cfg.Options)
+	if err != nil {
+		return nil, fmt.Errorf("delivery options: %w", err)
+	}
+	pickupOptions, err := convert(cfg.PickupOptions)
+	if err != nil {
+		return nil, fmt.Errorf("pickup options: %w", err)
+	}
+
+	return &entity.DeliveryTerms{
+		Delivery:        cfg.Delivery || len(deliveryOptions) > 0,
+		Pickup:          cfg.Pickup || len(pickupOptions) > 0,
+		Store:           cfg.Store,
+		DeliveryOptions: deliveryOptions,
+		PickupOptions:   pickupOptions,
+	}, nil
+}
+
+// applyProductDelivery задаёт индивидуальные условия доставки товарам с deliveryDays/orderBefore.
+// Стоимость берётся из первого варианта доставки магазина.
+func applyProductDelivery(products []entity.Product, terms *entity.DeliveryTerms) {
+	if !terms.Delivery || len(terms.DeliveryOptions) == 0 {
+		return
+	}
+	base := terms.DeliveryOptions[0]
+
+	for i := range products {
+		prod := &products[i]
+		if prod.DeliveryDays == nil && prod.OrderBefore == nil {
+			continue
+		}
+
+		option := base
+		if prod.DeliveryDays != nil && *prod.DeliveryDays >= 0 {
+			option.Days = strconv.Itoa(*prod.DeliveryDays)
+		}
+		if prod.OrderBefore != nil && *prod.OrderBefore >= 0 && *prod.OrderBefore <= 24 {
+			orderBefore := *prod.OrderBefore
+			option.OrderBefore = &orderBefore
+		}
+
+		prod.DeliveryOptions = []entity.DeliveryOption{option}
+	}
+}
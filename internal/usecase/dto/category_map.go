@@ -0,0 +1,32 @@
+package dto
+
+// UnmappedCategory описывает категорию товаров фида без соответствия категории площадки
+type UnmappedCategory struct {
+	CategoryID string `json:"category_id"`
+	Path       string `json:"path"`
+	Products   int    `json:"products"`
+}
+
+// CategorySuggestion описывает предложенное соответствие категории магазина категории площадки
+type CategorySuggestion struct {
+	CategoryID string  `json:"category_id"`
+	Category   string  `json:"category"` // Путь категории магазина
+	Key        string  `json:"key"`      // Ключ таблицы соответствия
+	Value      string  `json:"value"`    // Значение для таблицы (ID или путь категории площадки)
+	Label      string  `json:"label"`    // Путь категории площадки
+	Score      float64 `json:"score"`    // Похожесть названий от 0 до 1
+	Accepted   bool    `json:"accepted"` // Оценка не ниже порога, соответствие записано в таблицу
+}
+
+// SuggestCategoriesRequest содержит параметры подбора соответствий категорий
+type SuggestCategoriesRequest struct {
+	OutputPath string // Таблица соответствия с принятыми подсказками
+}
+
+// Validate проверяет валидность запроса
+func (r *SuggestCategoriesRequest) Validate() error {
+	if r.OutputPath == "" {
+		return ErrInvalidOutputPath
+	}
+	return nil
+}
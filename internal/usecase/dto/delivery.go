@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"fmt"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// DeliveryOptionConfig описывает ступень стоимости доставки или самовывоза
+type DeliveryOptionConfig struct {
+	Cost           string `json:"cost"`                       // Стоимость строкой, например "5.00"
+	Days           string `json:"days"`                       // Срок: "0", "2" или "1-3"
+	OrderBefore    *int   `json:"order_before,omitempty"`     // Час окончания приёма заказов
+	DeliveryTypeID int    `json:"delivery_type_id,omitempty"` // Способ доставки BeSeller; неактивные пропускаются
+}
+
+// DeliveryConfig описывает условия доставки фида
+type DeliveryConfig struct {
+	Delivery      bool                   `json:"delivery"`       // Есть курьерская доставка
+	Pickup        bool                   `json:"pickup"`         // Есть самовывоз
+	Store         bool                   `json:"store"`          // Можно купить в магазине
+	Options       []DeliveryOptionConfig `json:"options"`        // Варианты доставки
+	PickupOptions []DeliveryOptionConfig `json:"pickup_options"` // Варианты самовывоза
+}
+
+// Validate проверяет стоимость и сроки вариантов доставки
+func (c *DeliveryConfig) Validate() error {
+	for _, opt := range append(append([]DeliveryOptionConfig{}, c.Options...), c.PickupOptions...) {
+		if _, err := entity.ParseDecimal(opt.Cost); err != nil {
+			return fmt.Errorf("delivery option cost: %w", err)
+		}
+		if opt.Days == "" {
+			return ErrInvalidDeliveryDays
+		}
+		if opt.OrderBefore != nil && (*opt.OrderBefore < 0 || *opt.OrderBefore > 24) {
+			return ErrInvalidOrderBefore
+		}
+	}
+	return nil
+}
@@ -3,10 +3,21 @@ package dto
 import "errors"
 
 var (
-	ErrInvalidOutputPath  = errors.New("output path is required")
-	ErrInvalidShopName    = errors.New("shop name is required")
-	ErrInvalidShopCompany = errors.New("shop company is required")
-	ErrInvalidShopURL     = errors.New("shop URL is required")
-	ErrInvalidCurrency    = errors.New("currency is required")
-	ErrInvalidStatusID    = errors.New("status ID must be positive")
+	ErrInvalidOutputPath   = errors.New("output path is required")
+	ErrInvalidShopName     = errors.New("shop name is required")
+	ErrInvalidShopCompany  = errors.New("shop company is required")
+	ErrInvalidShopURL      = errors.New("shop URL is required")
+	ErrInvalidCurrency     = errors.New("currency is required")
+	ErrInvalidStatusID     = errors.New("status ID must be positive")
+	ErrInvalidReportPath   = errors.New("report path is required")
+	ErrInvalidLinkMode     = errors.New("link check mode must be off, flag or exclude")
+	ErrNoFeeds             = errors.New("at least one feed is required")
+	ErrInvalidFeedName     = errors.New("feed name is required")
+	ErrInvalidFeedFormat   = errors.New("feed format is required")
+	ErrDuplicateFeed       = errors.New("duplicate feed name")
+	ErrInvalidDeliveryDays = errors.New("delivery option days are required")
+	ErrInvalidOrderBefore  = errors.New("delivery option order_before must be between 0 and 24")
+	ErrInvalidKitMode      = errors.New("kits mode must be off, bundle or gift")
+	ErrInvalidSplitLimit   = errors.New("split requires positive max_offers or max_bytes")
+	ErrInvalidPublisher    = errors.New("publish target requires a publisher name")
 )
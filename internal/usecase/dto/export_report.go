@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+)
+
+// PriceChange описывает изменение цены товара правилом ценообразования
+type PriceChange struct {
+	ProductID string       `json:"product_id"`
+	Rule      string       `json:"rule"`
+	From      entity.Money `json:"from"`
+	To        entity.Money `json:"to"`
+}
+
+// SkipReason — код причины пропуска товара
+type SkipReason string
+
+const (
+	SkipInvalidProduct   SkipReason = "invalid_product"   // Данные товара не прошли проверку
+	SkipStatusFiltered   SkipReason = "status_filtered"   // Статус товара не совпадает с выгружаемым
+	SkipBrokenLink       SkipReason = "broken_link"       // Исключён проверкой ссылок
+	SkipUnmappedCategory SkipReason = "unmapped_category" // Категория не сопоставлена категории площадки
+	SkipFormatRejected   SkipReason = "format_rejected"   // Не соответствует требованиям формата фида
+)
+
+// SkippedOffer описывает товар, не попавший в выгрузку или в фид
+type SkippedOffer struct {
+	ProductID  string     `json:"product_id"`
+	Name       string     `json:"name"`
+	CategoryID string     `json:"category_id,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	Code       SkipReason `json:"code"`
+	Reason     string     `json:"reason"`
+}
+
+// PhaseTiming содержит длительность этапа запуска
+type PhaseTiming struct {
+	Name       string    `json:"name"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// APIOperationStats содержит статистику запросов одной операции API
+type APIOperationStats struct {
+	Requests   int   `json:"requests"`
+	Retries    int   `json:"retries,omitempty"`
+	Errors     int   `json:"errors,omitempty"`
+	DurationMs int64 `json:"duration_ms"`
+}
+
+// APIStats содержит статистику обращений к API за запуск
+type APIStats struct {
+	APIOperationStats
+	Operations map[string]APIOperationStats `json:"operations,omitempty"`
+}
+
+// CategoryCount содержит число выгружаемых и пропущенных товаров категории
+type CategoryCount struct {
+	CategoryID string `json:"category_id"`
+	Name       string `json:"name,omitempty"`
+	Products   int    `json:"products"`
+	Skipped    int    `json:"skipped,omitempty"`
+}
+
+// OutputFile описывает записанный файл фида
+type OutputFile struct {
+	Path   string `json:"path"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// FeedReport содержит результаты формирования одного фида
+type FeedReport struct {
+	Name         string         `json:"name"`
+	Format       string         `json:"format"`
+	OutputPath   string         `json:"output_path"`
+	Offers       int            `json:"offers"`
+	Bundles      int            `json:"bundles,omitempty"`
+	Promos       int            `json:"promos,omitempty"`
+	Reviews      int            `json:"reviews,omitempty"`
+	PriceChanges []PriceChange  `json:"price_changes,omitempty"`
+	Skipped      []SkippedOffer `json:"skipped,omitempty"`
+
+	UnmappedCategories []UnmappedCategory `json:"unmapped_categories,omitempty"`
+
+	Parts    []FeedPart   `json:"parts,omitempty"`    // Части фида при разбиении
+	Manifest string       `json:"manifest,omitempty"` // Путь к манифесту (для карты сайта — индексу) частей
+	Files    []OutputFile `json:"files,omitempty"`    // Записанные файлы с контрольными суммами
+
+	Published []PublishedFile `json:"published,omitempty"` // Опубликованные файлы фида
+}
+
+// ExportReport содержит результаты запуска экспорта
+type ExportReport struct {
+	RunID      string       `json:"run_id"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt time.Time    `json:"finished_at"`
+	Categories int          `json:"categories"`
+	Products   int          `json:"products"`
+	Feeds      []FeedReport `json:"feeds"`
+	Error      string       `json:"error,omitempty"`
+
+	Phases         []PhaseTiming   `json:"phases"`                    // Длительность этапов
+	API            *APIStats       `json:"api,omitempty"`             // Обращения к API
+	CategoryCounts []CategoryCount `json:"category_counts,omitempty"` // Товары по категориям
+	Skipped        []SkippedOffer  `json:"skipped,omitempty"`         // Товары, не попавшие в выгрузку
+}
+
+// AddPhase добавляет в отчёт этап, начавшийся в started и завершившийся сейчас
+func (r *ExportReport) AddPhase(name string, started time.Time) {
+	r.Phases = append(r.Phases, PhaseTiming{
+		Name:       name,
+		StartedAt:  started.UTC(),
+		DurationMs: time.Since(started).Milliseconds(),
+	})
+}
@@ -1,19 +1,125 @@
 package dto
 
+import (
+	"fmt"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/domain/pricing"
+)
+
+// KitMode определяет, как комплекты товаров выводятся в фиде
+type KitMode string
+
+const (
+	KitModeOff    KitMode = "off"    // Комплекты не выводятся
+	KitModeBundle KitMode = "bundle" // Комплект — отдельное предложение с суммарной ценой
+	KitModeGift   KitMode = "gift"   // Комплект — акция «подарок при покупке»
+)
+
+// FeedRequest содержит параметры одного выходного фида
+type FeedRequest struct {
+	Name       string            // Уникальное имя фида
+	Format     string            // Формат writer'а (yml)
+	OutputPath string            // Путь к выходному файлу
+	Tracking   map[string]string // UTM/трекинг параметры, добавляемые к URL товаров
+	Pricing    []pricing.Rule    // Правила ценообразования фида, применяются по порядку
+	Currency   string            // Валюта фида (пусто — валюта магазина без конвертации)
+	Rates      map[string]string // Курсы: единиц валюты фида за единицу исходной валюты
+
+	VendorModelCategories []string // Категории (с поддеревом), выгружаемые как vendor.model
+
+	Delivery *DeliveryConfig // Условия доставки, самовывоза и покупки в магазине
+	Promos   bool            // Выводить акции по промокодам BeSeller
+	Kits     KitMode         // Вывод комплектов товаров (off, bundle, gift)
+	Ratings  bool            // Добавлять сводный рейтинг к предложениям
+	Split    *SplitConfig    // Разбиение фида на части (nil — один файл)
+	Publish  []PublishTarget // Публикация записанных файлов после успешной выгрузки
+}
+
+// TargetCurrency возвращает валюту фида или валюту по умолчанию
+func (f *FeedRequest) TargetCurrency(defaultCurrency string) string {
+	if f.Currency != "" {
+		return f.Currency
+	}
+	return defaultCurrency
+}
+
+// UsesKits проверяет, выводит ли фид комплекты товаров
+func (f *FeedRequest) UsesKits() bool {
+	return f.Kits == KitModeBundle || f.Kits == KitModeGift
+}
+
+// Validate проверяет валидность параметров фида
+func (f *FeedRequest) Validate() error {
+	if f.Name == "" {
+		return ErrInvalidFeedName
+	}
+	if f.OutputPath == "" {
+		return fmt.Errorf("feed %s: %w", f.Name, ErrInvalidOutputPath)
+	}
+	if f.Format == "" {
+		return fmt.Errorf("feed %s: %w", f.Name, ErrInvalidFeedFormat)
+	}
+	for currency, rate := range f.Rates {
+		if _, err := entity.ParseDecimal(rate); err != nil {
+			return fmt.Errorf("feed %s: rate %s: %w", f.Name, currency, err)
+		}
+	}
+	switch f.Kits {
+	case "", KitModeOff, KitModeBundle, KitModeGift:
+	default:
+		return fmt.Errorf("feed %s: %w", f.Name, ErrInvalidKitMode)
+	}
+	if f.Delivery != nil {
+		if err := f.Delivery.Validate(); err != nil {
+			return fmt.Errorf("feed %s: %w", f.Name, err)
+		}
+	}
+	if f.Split != nil {
+		if err := f.Split.Validate(); err != nil {
+			return fmt.Errorf("feed %s: %w", f.Name, err)
+		}
+	}
+	for i := range f.Publish {
+		if err := f.Publish[i].Validate(); err != nil {
+			return fmt.Errorf("feed %s: %w", f.Name, err)
+		}
+	}
+	for i := range f.Pricing {
+		if err := f.Pricing[i].Validate(); err != nil {
+			return fmt.Errorf("feed %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
 // ExportRequest содержит параметры для экспорта каталога в YML
 type ExportRequest struct {
-	OutputPath  string // Путь к выходному YML файлу
-	ShopName    string // Название магазина
-	ShopCompany string // Название компании
-	ShopURL     string // URL магазина
-	Currency    string // Валюта магазина (BYN, USD, RUB и т.д.)
-	StatusID    int    // ID статуса товаров для экспорта (1 = новинка)
+	Feeds       []FeedRequest // Фиды, формируемые из одного загруженного каталога
+	ShopName    string        // Название магазина
+	ShopCompany string        // Название компании
+	ShopURL     string        // URL магазина
+	Currency    string        // Валюта магазина (BYN, USD, RUB и т.д.)
+	StatusID    int           // ID статуса товаров для экспорта (1 = новинка)
+
+	LinkCheckMode  LinkCheckMode // Проверка ссылок перед записью (off, flag, exclude)
+	LinkReportPath string        // Путь к отчёту проверки ссылок
 }
 
 // Validate проверяет валидность запроса
 func (r *ExportRequest) Validate() error {
-	if r.OutputPath == "" {
-		return ErrInvalidOutputPath
+	if len(r.Feeds) == 0 {
+		return ErrNoFeeds
+	}
+	names := make(map[string]bool, len(r.Feeds))
+	for i := range r.Feeds {
+		if err := r.Feeds[i].Validate(); err != nil {
+			return err
+		}
+		if names[r.Feeds[i].Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateFeed, r.Feeds[i].Name)
+		}
+		names[r.Feeds[i].Name] = true
 	}
 	if r.ShopName == "" {
 		return ErrInvalidShopName
@@ -30,5 +136,14 @@ func (r *ExportRequest) Validate() error {
 	if r.StatusID <= 0 {
 		return ErrInvalidStatusID
 	}
+	switch r.LinkCheckMode {
+	case "", LinkCheckOff:
+	case LinkCheckFlag, LinkCheckExclude:
+		if r.LinkReportPath == "" {
+			return ErrInvalidReportPath
+		}
+	default:
+		return ErrInvalidLinkMode
+	}
 	return nil
 }
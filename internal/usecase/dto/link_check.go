@@ -0,0 +1,75 @@
+package dto
+
+import "time"
+
+// LinkKind определяет тип проверяемой ссылки
+type LinkKind string
+
+const (
+	LinkKindPage  LinkKind = "page"  // Страница товара (offer url)
+	LinkKindImage LinkKind = "image" // Изображение товара (offer picture)
+)
+
+// LinkCheckMode определяет, как результаты проверки ссылок влияют на экспорт
+type LinkCheckMode string
+
+const (
+	LinkCheckOff     LinkCheckMode = "off"     // Проверка не выполняется
+	LinkCheckFlag    LinkCheckMode = "flag"    // Проблемные товары только попадают в отчёт
+	LinkCheckExclude LinkCheckMode = "exclude" // Проблемные товары исключаются из выгрузки
+)
+
+// LinkTarget представляет ссылку для проверки
+type LinkTarget struct {
+	URL       string   // Проверяемый URL
+	Kind      LinkKind // Тип ссылки
+	ProductID string   // Товар, к которому относится ссылка
+}
+
+// LinkResult представляет результат проверки одной ссылки
+type LinkResult struct {
+	URL         string    `json:"url"`
+	Kind        LinkKind  `json:"kind"`
+	OK          bool      `json:"ok"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Width       int       `json:"width,omitempty"`
+	Height      int       `json:"height,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Cached      bool      `json:"cached,omitempty"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// LinkProductIssue описывает товар с недоступными ссылками
+type LinkProductIssue struct {
+	ProductID string       `json:"product_id"`
+	Excluded  bool         `json:"excluded"`
+	Broken    []LinkResult `json:"broken"`
+}
+
+// LinkCheckReport представляет отчёт о проверке ссылок
+type LinkCheckReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Mode        LinkCheckMode      `json:"mode"`
+	Checked     int                `json:"checked"`
+	Broken      int                `json:"broken"`
+	FromCache   int                `json:"from_cache"`
+	Products    []LinkProductIssue `json:"products"`
+}
+
+// CheckLinksRequest содержит параметры проверки ссылок
+type CheckLinksRequest struct {
+	StatusID   int    // ID статуса проверяемых товаров
+	ReportPath string // Путь к JSON отчёту
+}
+
+// Validate проверяет валидность запроса
+func (r *CheckLinksRequest) Validate() error {
+	if r.StatusID <= 0 {
+		return ErrInvalidStatusID
+	}
+	if r.ReportPath == "" {
+		return ErrInvalidReportPath
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package dto
+
+import "time"
+
+// NotificationLevel определяет результат запуска, о котором отправляется уведомление
+type NotificationLevel string
+
+const (
+	NotificationSuccess NotificationLevel = "success" // Запуск без замечаний
+	NotificationWarning NotificationLevel = "warning" // Запуск завершён, но есть аномалии
+	NotificationFailure NotificationLevel = "failure" // Запуск завершился ошибкой
+)
+
+// NotificationRoute направляет уведомления о фидах в канал
+type NotificationRoute struct {
+	Sink  string              `json:"sink"`  // Имя канала из раздела sinks
+	Feeds []string            `json:"feeds"` // Фиды, о которых сообщается (пусто — все)
+	On    []NotificationLevel `json:"on"`    // Уровни (пусто — warning и failure)
+}
+
+// Accepts проверяет, отправляется ли уведомление уровня level по маршруту
+func (r *NotificationRoute) Accepts(level NotificationLevel) bool {
+	if len(r.On) == 0 {
+		return level == NotificationWarning || level == NotificationFailure
+	}
+	for _, on := range r.On {
+		if on == level {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches проверяет, относится ли фид к маршруту
+func (r *NotificationRoute) Matches(feed string) bool {
+	if len(r.Feeds) == 0 {
+		return true
+	}
+	for _, name := range r.Feeds {
+		if name == feed {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyRequest содержит данные для уведомлений о запуске
+type NotifyRequest struct {
+	Report   *ExportReport       // Отчёт текущего запуска
+	Previous *ExportReport       // Отчёт предыдущего запуска для сравнения (может быть nil)
+	Routes   []NotificationRoute // Маршруты уведомлений
+
+	MaxOfferDropPercent float64 // Падение числа предложений фида, считающееся аномалией
+	MaxSkipped          int     // Число пропущенных товаров, сверх которого запуск получает warning (0 — без порога)
+	MaxSkippedPercent   float64 // Доля пропущенных товаров в процентах, сверх которой запуск получает warning (0 — без порога)
+}
+
+// FeedSummary содержит сводку по фиду для уведомления
+type FeedSummary struct {
+	Name           string         `json:"name"`
+	Offers         int            `json:"offers"`
+	PreviousOffers *int           `json:"previous_offers,omitempty"`
+	Skipped        int            `json:"skipped,omitempty"`
+	SkipReasons    map[string]int `json:"skip_reasons,omitempty"` // Число пропусков по коду причины
+	Unmapped       int            `json:"unmapped_categories,omitempty"`
+	Warnings       []string       `json:"warnings,omitempty"`
+}
+
+// Notification содержит уведомление о запуске экспорта
+type Notification struct {
+	RunID      string            `json:"run_id"`
+	Level      NotificationLevel `json:"level"`
+	Title      string            `json:"title"`
+	Text       string            `json:"text"` // Сводка в виде текста для почты и чатов
+	StartedAt  time.Time         `json:"started_at"`
+	Duration   string            `json:"duration"`
+	Products   int               `json:"products"`
+	Categories int               `json:"categories"`
+	Error      string            `json:"error,omitempty"`
+	Skipped    int               `json:"skipped,omitempty"` // Товары, не попавшие в выгрузку до формирования фидов
+	Warnings   []string          `json:"warnings,omitempty"`
+	Feeds      []FeedSummary     `json:"feeds"`
+
+	SkipReasons map[string]int `json:"skip_reasons,omitempty"` // Пропуски запуска по коду причины
+}
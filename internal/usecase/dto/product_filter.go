@@ -0,0 +1,16 @@
+package dto
+
+// ProductFilter описывает отбор товаров фида на стороне API (ProductFilter схемы BeSeller)
+type ProductFilter struct {
+	ShopByClassification string // Значение ShopByClassificationEnum (пусто — без отбора)
+}
+
+// IsEmpty проверяет, что фильтр не ограничивает товары
+func (f ProductFilter) IsEmpty() bool {
+	return f.ShopByClassification == ""
+}
+
+// String возвращает описание фильтра для отчёта
+func (f ProductFilter) String() string {
+	return "shopByClassification=" + f.ShopByClassification
+}
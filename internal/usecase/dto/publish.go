@@ -0,0 +1,23 @@
+package dto
+
+// PublishTarget описывает публикацию файлов фида через один из публикаторов
+type PublishTarget struct {
+	Publisher    string `json:"publisher"`     // Имя публикатора из раздела publishers
+	Dir          string `json:"dir"`           // Каталог (префикс ключа) назначения относительно корня публикатора
+	ContentType  string `json:"content_type"`  // Content-Type (пусто — настройка публикатора или по расширению)
+	CacheControl string `json:"cache_control"` // Cache-Control (пусто — настройка публикатора)
+}
+
+// Validate проверяет цель публикации
+func (t *PublishTarget) Validate() error {
+	if t.Publisher == "" {
+		return ErrInvalidPublisher
+	}
+	return nil
+}
+
+// PublishedFile описывает опубликованный файл фида
+type PublishedFile struct {
+	Publisher string `json:"publisher"`
+	Location  string `json:"location"` // Адрес файла в месте назначения (путь, sftp://, s3://, URL)
+}
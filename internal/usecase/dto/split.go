@@ -0,0 +1,25 @@
+package dto
+
+// SplitConfig описывает разбиение фида на части
+type SplitConfig struct {
+	MaxOffers int   `json:"max_offers"` // Максимум предложений в части (0 — без ограничения)
+	MaxBytes  int64 `json:"max_bytes"`  // Максимальный размер файла части в байтах (0 — без ограничения)
+}
+
+// Validate проверяет ограничения разбиения
+func (c *SplitConfig) Validate() error {
+	if c.MaxOffers < 0 || c.MaxBytes < 0 {
+		return ErrInvalidSplitLimit
+	}
+	if c.MaxOffers == 0 && c.MaxBytes == 0 {
+		return ErrInvalidSplitLimit
+	}
+	return nil
+}
+
+// FeedPart описывает записанную часть фида
+type FeedPart struct {
+	Path   string `json:"path"`
+	Offers int    `json:"offers"`
+	Bytes  int64  `json:"bytes"`
+}
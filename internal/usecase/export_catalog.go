@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/domain/pricing"
 	"beseller-yml-exporter/internal/domain/repository"
 	"beseller-yml-exporter/internal/usecase/dto"
 )
@@ -19,47 +21,106 @@ type Logger interface {
 
 // CatalogWriter определяет интерфейс для записи каталога в файл
 type CatalogWriter interface {
-	Write(
-		outputPath string,
-		shopName string,
-		shopCompany string,
-		shopURL string,
-		currency string,
-		categories []entity.Category,
-		products []entity.Product,
-	) error
+	Write(outputPath string, catalog entity.Catalog) error
+}
+
+// URLDecorator определяет интерфейс добавления трекинг-параметров к URL
+type URLDecorator interface {
+	Decorate(rawURL string, params map[string]string, vars map[string]string) (string, error)
 }
 
 // ExportCatalogUseCase реализует сценарий экспорта каталога в YML
 type ExportCatalogUseCase struct {
 	catalogRepo repository.CatalogRepository
-	writer      CatalogWriter
+	kitRepo     repository.KitRepository // может быть nil, если комплекты не выгружаются
+	writers     map[string]CatalogWriter // writer'ы по формату фида
+	decorator   URLDecorator
+	linkChecker LinkChecker          // может быть nil, если проверка ссылок не используется
+	splitter    FeedSplitter         // может быть nil, если фиды не разбиваются на части
+	inspector   FileInspector        // может быть nil, если контрольные суммы не нужны
+	publishers  map[string]Publisher // публикаторы по имени из настроек
 	logger      Logger
 }
 
 // NewExportCatalogUseCase создаёт новый экземпляр use case
 func NewExportCatalogUseCase(
 	catalogRepo repository.CatalogRepository,
-	writer CatalogWriter,
+	kitRepo repository.KitRepository,
+	writers map[string]CatalogWriter,
+	decorator URLDecorator,
+	linkChecker LinkChecker,
+	splitter FeedSplitter,
+	inspector FileInspector,
+	publishers map[string]Publisher,
 	logger Logger,
 ) *ExportCatalogUseCase {
 	return &ExportCatalogUseCase{
 		catalogRepo: catalogRepo,
-		writer:      writer,
+		kitRepo:     kitRepo,
+		writers:     writers,
+		decorator:   decorator,
+		linkChecker: linkChecker,
+		splitter:    splitter,
+		inspector:   inspector,
+		publishers:  publishers,
 		logger:      logger,
 	}
 }
 
-// Execute выполняет экспорт каталога
-func (uc *ExportCatalogUseCase) Execute(ctx context.Context, req dto.ExportRequest) error {
+// Execute выполняет экспорт каталога и возвращает отчёт о запуске.
+// Отчёт возвращается и при ошибке, содержа результаты до момента сбоя.
+func (uc *ExportCatalogUseCase) Execute(ctx context.Context, req dto.ExportRequest) (*dto.ExportReport, error) {
+	started := time.Now().UTC()
+	report := &dto.ExportReport{
+		RunID:     newRunID(started),
+		StartedAt: started,
+		Feeds:     []dto.FeedReport{},
+		Phases:    []dto.PhaseTiming{},
+	}
+
+	err := uc.execute(ctx, req, report)
+
+	report.FinishedAt = time.Now().UTC()
+	if source, ok := uc.catalogRepo.(APIStatsSource); ok {
+		stats := source.APIStats()
+		report.API = &stats
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	return report, err
+}
+
+// execute выполняет шаги экспорта, заполняя отчёт
+func (uc *ExportCatalogUseCase) execute(ctx context.Context, req dto.ExportRequest, report *dto.ExportReport) error {
 	// Валидация запроса
 	if err := req.Validate(); err != nil {
 		return fmt.Errorf("invalid request: %w", err)
 	}
+	for _, feed := range req.Feeds {
+		writer, ok := uc.writers[feed.Format]
+		if !ok {
+			return fmt.Errorf("invalid request: feed %s: unsupported format %q", feed.Name, feed.Format)
+		}
+		// Формат, разбивающий вывод сам, не сочетается с внешним разбиением
+		if _, ok := writer.(PartsWriter); ok && feed.Split != nil {
+			return fmt.Errorf("invalid request: feed %s: %s format splits output itself, split is not supported", feed.Name, feed.Format)
+		}
+		if feed.Split != nil && uc.splitter == nil {
+			return fmt.Errorf("invalid request: feed %s: split requires a feed splitter", feed.Name)
+		}
+		for _, target := range feed.Publish {
+			if _, ok := uc.publishers[target.Publisher]; !ok {
+				return fmt.Errorf("invalid request: feed %s: unknown publisher %q", feed.Name, target.Publisher)
+			}
+		}
+	}
 
 	// 1. Получение категорий
 	uc.logger.Info("Fetching categories...")
+	phaseStarted := time.Now()
 	categories, err := uc.catalogRepo.GetCategories(ctx)
+	report.AddPhase("fetch_categories", phaseStarted)
 	if err != nil {
 		return fmt.Errorf("failed to fetch categories: %w", err)
 	}
@@ -77,7 +138,9 @@ func (uc *ExportCatalogUseCase) Execute(ctx context.Context, req dto.ExportReque
 
 	// 2. Получение товаров с нужным статусом
 	uc.logger.Info(fmt.Sprintf("Fetching products with statusId=%d...", req.StatusID))
+	phaseStarted = time.Now()
 	products, err := uc.catalogRepo.GetProductsByStatus(ctx, req.StatusID)
+	report.AddPhase("fetch_products", phaseStarted)
 	if err != nil {
 		return fmt.Errorf("failed to fetch products: %w", err)
 	}
@@ -88,36 +151,423 @@ func (uc *ExportCatalogUseCase) Execute(ctx context.Context, req dto.ExportReque
 	for _, prod := range products {
 		if err := prod.Validate(); err != nil {
 			uc.logger.Warn(fmt.Sprintf("Skipping invalid product %s: %v", prod.ID, err))
+			report.Skipped = append(report.Skipped, newSkippedOffer(&prod, dto.SkipInvalidProduct, err.Error()))
 			continue
 		}
 		// Дополнительная проверка статуса (на случай если API вернул лишнее)
 		if !prod.IsNew() {
 			uc.logger.Debug(fmt.Sprintf("Skipping product %s: statusId=%d", prod.ID, prod.StatusID))
+			report.Skipped = append(report.Skipped, newSkippedOffer(&prod, dto.SkipStatusFiltered, fmt.Sprintf("statusId=%d", prod.StatusID)))
 			continue
 		}
 		validProducts = append(validProducts, prod)
 	}
 
+	// Проверка доступности URL и изображений
+	if req.LinkCheckMode == dto.LinkCheckFlag || req.LinkCheckMode == dto.LinkCheckExclude {
+		if uc.linkChecker == nil {
+			return fmt.Errorf("link check mode %q requires a link checker", req.LinkCheckMode)
+		}
+		phaseStarted = time.Now()
+		checked, linkReport, err := checkProductLinks(ctx, uc.linkChecker, uc.logger, validProducts, req.LinkCheckMode)
+		report.AddPhase("check_links", phaseStarted)
+		if err != nil {
+			return err
+		}
+		if err := uc.linkChecker.WriteReport(req.LinkReportPath, linkReport); err != nil {
+			return fmt.Errorf("failed to write link report: %w", err)
+		}
+		uc.logger.Info(fmt.Sprintf("Link report created: %s (broken=%d)", req.LinkReportPath, linkReport.Broken))
+		report.Skipped = append(report.Skipped, linkCheckSkips(validProducts, linkReport)...)
+		validProducts = checked
+	}
+
 	if len(validProducts) == 0 {
 		uc.logger.Warn("No valid products found for export")
 	}
 
-	// 3. Запись в YML файл
-	uc.logger.Info("Generating YML file...")
-	if err := uc.writer.Write(
-		req.OutputPath,
-		req.ShopName,
-		req.ShopCompany,
-		req.ShopURL,
-		req.Currency,
-		validCategories,
-		validProducts,
-	); err != nil {
-		return fmt.Errorf("failed to write YML: %w", err)
+	report.Categories = len(validCategories)
+	report.Products = len(validProducts)
+	tree := entity.NewCategoryTree(validCategories)
+	report.CategoryCounts = categoryCounts(tree, validProducts, report.Skipped)
+
+	// Способы доставки загружаются один раз, если хотя бы один фид выводит доставку
+	var deliveryTypes []entity.DeliveryType
+	for _, feed := range req.Feeds {
+		if feed.Delivery == nil {
+			continue
+		}
+		uc.logger.Info("Fetching delivery types...")
+		phaseStarted = time.Now()
+		deliveryTypes, err = uc.catalogRepo.GetDeliveryTypes(ctx)
+		report.AddPhase("fetch_delivery_types", phaseStarted)
+		if err != nil {
+			return fmt.Errorf("failed to fetch delivery types: %w", err)
+		}
+		break
 	}
 
-	uc.logger.Info(fmt.Sprintf("YML file created: %s", req.OutputPath))
-	uc.logger.Info(fmt.Sprintf("Export completed (categories=%d, offers=%d)", len(validCategories), len(validProducts)))
+	// Промокоды загружаются один раз, если хотя бы один фид выводит акции
+	var discountCodes []entity.DiscountCode
+	for _, feed := range req.Feeds {
+		if !feed.Promos {
+			continue
+		}
+		uc.logger.Info("Fetching discount codes...")
+		phaseStarted = time.Now()
+		discountCodes, err = uc.catalogRepo.GetDiscountCodes(ctx)
+		report.AddPhase("fetch_discount_codes", phaseStarted)
+		if err != nil {
+			return fmt.Errorf("failed to fetch discount codes: %w", err)
+		}
+		break
+	}
+
+	// Отзывы загружаются один раз, если хотя бы одному формату они нужны
+	var reviews []entity.Review
+	for _, feed := range req.Feeds {
+		if !usesReviews(uc.writers[feed.Format]) {
+			continue
+		}
+		uc.logger.Info("Fetching accepted reviews...")
+		phaseStarted = time.Now()
+		reviews, err = uc.catalogRepo.GetAcceptedReviews(ctx)
+		report.AddPhase("fetch_reviews", phaseStarted)
+		if err != nil {
+			return fmt.Errorf("failed to fetch reviews: %w", err)
+		}
+		break
+	}
+
+	// Комплекты загружаются один раз, если хотя бы один фид их выводит
+	var kits []entity.ProductKit
+	for _, feed := range req.Feeds {
+		if !feed.UsesKits() {
+			continue
+		}
+		if uc.kitRepo == nil {
+			return fmt.Errorf("feed %s: kits mode %q requires a kits source", feed.Name, feed.Kits)
+		}
+		uc.logger.Info("Fetching product kits...")
+		phaseStarted = time.Now()
+		kits, err = uc.kitRepo.GetProductKits(ctx)
+		report.AddPhase("fetch_kits", phaseStarted)
+		if err != nil {
+			return fmt.Errorf("failed to fetch product kits: %w", err)
+		}
+		break
+	}
+
+	// Товары, отобранные фильтром API, загружаются один раз для каждого фильтра
+	filteredIDs := make(map[dto.ProductFilter]map[string]bool)
+	for _, feed := range req.Feeds {
+		filter, ok := productFilter(uc.writers[feed.Format])
+		if !ok {
+			continue
+		}
+		if _, ok := filteredIDs[filter]; ok {
+			continue
+		}
+		source, ok := uc.catalogRepo.(FilteredProductSource)
+		if !ok {
+			return fmt.Errorf("feed %s: %s format requires a catalog source with product filters", feed.Name, feed.Format)
+		}
+		uc.logger.Info(fmt.Sprintf("Fetching products by %s...", filter))
+		phaseStarted = time.Now()
+		productIDs, err := source.GetProductIDs(ctx, req.StatusID, filter)
+		report.AddPhase("fetch_filter:"+filter.String(), phaseStarted)
+		if err != nil {
+			return fmt.Errorf("failed to fetch products by %s: %w", filter, err)
+		}
+		ids := make(map[string]bool, len(productIDs))
+		for _, id := range productIDs {
+			ids[id] = true
+		}
+		filteredIDs[filter] = ids
+	}
+
+	// 3. Формирование и запись фидов
+	for _, feed := range req.Feeds {
+		phaseStarted = time.Now()
+
+		// Комплекты как отдельные предложения собираются из исходных цен и URL,
+		// а наценки и трекинг получают вместе с остальными предложениями фида
+		offers := validProducts
+		var bundles []entity.Product
+		if feed.Kits == dto.KitModeBundle {
+			if bundles, err = uc.buildBundles(feed, kits, validProducts); err != nil {
+				return fmt.Errorf("feed %s: %w", feed.Name, err)
+			}
+			offers = make([]entity.Product, 0, len(validProducts)+len(bundles))
+			offers = append(append(offers, validProducts...), bundles...)
+		}
+
+		feedProducts, priceChanges, err := uc.prepareFeed(feed, tree, offers)
+		if err != nil {
+			return fmt.Errorf("failed to prepare feed %s: %w", feed.Name, err)
+		}
+
+		// Формат может отбирать товары фильтром API
+		writer := uc.writers[feed.Format]
+		var filtered []dto.SkippedOffer
+		if filter, ok := productFilter(writer); ok {
+			feedProducts, filtered = filterProducts(feedProducts, filteredIDs[filter], filter)
+			if len(filtered) > 0 {
+				uc.logger.Info(fmt.Sprintf("Feed %s: %d products not matched by %s", feed.Name, len(filtered), filter))
+			}
+		}
+
+		// Категории без соответствия категориям площадки попадают в отчёт
+		unmapped := unmappedCategories(writer, tree, feedProducts)
+		if len(unmapped) > 0 {
+			uc.logger.Warn(fmt.Sprintf("Feed %s: %d categories are not mapped for %s format, see run report", feed.Name, len(unmapped), feed.Format))
+		}
+
+		// Форматы маркетплейсов принимают только подходящие им товары
+		feedProducts, skipped := selectOffers(writer, tree, feedProducts, unmapped)
+		skipped = append(filtered, skipped...)
+		if len(skipped) > 0 {
+			uc.logger.Warn(fmt.Sprintf("Feed %s: %d products skipped by %s format, see run report", feed.Name, len(skipped), feed.Format))
+		}
+
+		shop := entity.Shop{
+			Name:     req.ShopName,
+			Company:  req.ShopCompany,
+			URL:      req.ShopURL,
+			Currency: feed.TargetCurrency(req.Currency),
+		}
+		if feed.Delivery != nil {
+			terms, err := uc.buildDeliveryTerms(*feed.Delivery, shop.Currency, deliveryTypes)
+			if err != nil {
+				return fmt.Errorf("feed %s: %w", feed.Name, err)
+			}
+			shop.Delivery = terms
+			applyProductDelivery(feedProducts, terms)
+		}
+
+		var promos []entity.Promo
+		if feed.Promos {
+			if promos, err = uc.buildPromos(feed, discountCodes, validCategories, feedProducts, req.Currency, time.Now()); err != nil {
+				return fmt.Errorf("feed %s: %w", feed.Name, err)
+			}
+			uc.logger.Info(fmt.Sprintf("Feed %s: %d promos", feed.Name, len(promos)))
+		}
+		if feed.Kits == dto.KitModeGift {
+			promos = append(promos, uc.buildGiftPromos(feed, kits, feedProducts)...)
+		}
+
+		var feedReviews []entity.Review
+		if usesReviews(writer) {
+			feedReviews = linkReviews(reviews, feedProducts)
+			uc.logger.Info(fmt.Sprintf("Feed %s: %d of %d reviews linked to offers", feed.Name, len(feedReviews), len(reviews)))
+		}
+
+		catalog := entity.Catalog{
+			Shop:       shop,
+			Categories: validCategories,
+			Products:   feedProducts,
+			Promos:     promos,
+			Reviews:    feedReviews,
+		}
+
+		uc.logger.Info(fmt.Sprintf("Generating %s feed %s...", feed.Format, feed.Name))
+		var parts []dto.FeedPart
+		var manifest string
+		if feed.Split != nil {
+			if parts, manifest, err = uc.splitter.WriteParts(writer.Write, feed.OutputPath, catalog, *feed.Split); err != nil {
+				return fmt.Errorf("failed to write feed %s: %w", feed.Name, err)
+			}
+			uc.logger.Info(fmt.Sprintf("Feed %s created: %d parts, manifest %s", feed.Name, len(parts), manifest))
+		} else if partsWriter, ok := writer.(PartsWriter); ok {
+			if parts, err = partsWriter.WriteParts(feed.OutputPath, catalog); err != nil {
+				return fmt.Errorf("failed to write feed %s: %w", feed.Name, err)
+			}
+			if len(parts) > 0 {
+				// Индекс ссылается на части, поэтому публикуется после них, как манифест
+				manifest = feed.OutputPath
+				uc.logger.Info(fmt.Sprintf("Feed %s created: %d parts, index %s", feed.Name, len(parts), manifest))
+			} else {
+				uc.logger.Info(fmt.Sprintf("Feed %s created: %s", feed.Name, feed.OutputPath))
+			}
+		} else {
+			if err := writer.Write(feed.OutputPath, catalog); err != nil {
+				return fmt.Errorf("failed to write feed %s: %w", feed.Name, err)
+			}
+			uc.logger.Info(fmt.Sprintf("Feed %s created: %s", feed.Name, feed.OutputPath))
+		}
+
+		feedReport := dto.FeedReport{
+			Name:         feed.Name,
+			Format:       feed.Format,
+			OutputPath:   feed.OutputPath,
+			Offers:       len(feedProducts),
+			Bundles:      len(bundles),
+			Promos:       len(promos),
+			Reviews:      len(feedReviews),
+			PriceChanges: priceChanges,
+			Skipped:      skipped,
+
+			UnmappedCategories: unmapped,
+
+			Parts:    parts,
+			Manifest: manifest,
+		}
+		if uc.inspector != nil {
+			for _, path := range feedFiles(feedReport) {
+				file, err := uc.inspector.Inspect(path)
+				if err != nil {
+					return fmt.Errorf("failed to inspect feed %s: %w", feed.Name, err)
+				}
+				feedReport.Files = append(feedReport.Files, file)
+			}
+		}
+		report.Feeds = append(report.Feeds, feedReport)
+		report.AddPhase("feed:"+feed.Name, phaseStarted)
+	}
+
+	// 4. Публикация — только после успешной записи всех фидов, чтобы при сбое
+	// в местах назначения оставались файлы предыдущей успешной выгрузки
+	for i, feed := range req.Feeds {
+		if len(feed.Publish) == 0 {
+			continue
+		}
+		phaseStarted = time.Now()
+		err := uc.publishFeed(ctx, feed, &report.Feeds[i])
+		report.AddPhase("publish:"+feed.Name, phaseStarted)
+		if err != nil {
+			return fmt.Errorf("failed to publish feed %s: %w", feed.Name, err)
+		}
+	}
+
+	uc.logger.Info(fmt.Sprintf("Export completed (feeds=%d, categories=%d, offers=%d)", len(req.Feeds), len(validCategories), len(validProducts)))
 
 	return nil
 }
+
+// prepareFeed возвращает копию товаров, подготовленную для конкретного фида
+func (uc *ExportCatalogUseCase) prepareFeed(
+	feed dto.FeedRequest,
+	tree *entity.CategoryTree,
+	products []entity.Product,
+) ([]entity.Product, []dto.PriceChange, error) {
+	prepared := make([]entity.Product, len(products))
+	copy(prepared, products)
+
+	// Сводный рейтинг выводится только фидами, в которых он включён
+	if !feed.Ratings {
+		for i := range prepared {
+			prepared[i].Rating = nil
+		}
+	}
+
+	// Конвертация валюты выполняется до правил ценообразования
+	if feed.Currency != "" {
+		for i := range prepared {
+			converted, err := convertPrice(prepared[i].Price, feed.Currency, feed.Rates)
+			if err != nil {
+				return nil, nil, fmt.Errorf("product %s: %w", prepared[i].ID, err)
+			}
+			prepared[i].Price = converted
+		}
+	}
+
+	// Правила ценообразования
+	var priceChanges []dto.PriceChange
+	if len(feed.Pricing) > 0 {
+		engine, err := pricing.NewEngine(feed.Pricing, tree)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range prepared {
+			for _, change := range engine.Apply(&prepared[i]) {
+				priceChanges = append(priceChanges, dto.PriceChange{
+					ProductID: change.ProductID,
+					Rule:      change.Rule,
+					From:      change.From,
+					To:        change.To,
+				})
+			}
+		}
+		uc.logger.Info(fmt.Sprintf("Feed %s: pricing rules changed %d prices", feed.Name, len(priceChanges)))
+	}
+
+	// Тип предложения vendor.model для выбранных категорий
+	if len(feed.VendorModelCategories) > 0 {
+		converted := 0
+		for i := range prepared {
+			prod := &prepared[i]
+			if !inCategories(tree, prod.CategoryID, feed.VendorModelCategories) {
+				continue
+			}
+			categoryName := ""
+			if cat, ok := tree.Get(prod.CategoryID); ok {
+				categoryName = cat.Name
+			}
+			candidate := *prod
+			if err := candidate.ApplyVendorModel(categoryName); err != nil {
+				// Неполное предложение выгружается в упрощённом виде
+				uc.logger.Warn(fmt.Sprintf("Feed %s: product %s exported as simplified offer: %v", feed.Name, prod.ID, err))
+				continue
+			}
+			*prod = candidate
+			converted++
+		}
+		uc.logger.Info(fmt.Sprintf("Feed %s: %d vendor.model offers", feed.Name, converted))
+	}
+
+	// Трекинг-параметры URL
+	if len(feed.Tracking) > 0 {
+		for i := range prepared {
+			prod := &prepared[i]
+			decorated, err := uc.decorator.Decorate(prod.URL, feed.Tracking, trackingVars(feed, tree, prod))
+			if err != nil {
+				return nil, nil, fmt.Errorf("product %s: %w", prod.ID, err)
+			}
+			prod.URL = decorated
+		}
+	}
+
+	return prepared, priceChanges, nil
+}
+
+// inCategories проверяет, входит ли категория в поддерево одной из указанных
+func inCategories(tree *entity.CategoryTree, categoryID string, ancestors []string) bool {
+	for _, ancestorID := range ancestors {
+		if categoryID == ancestorID || tree.IsDescendant(categoryID, ancestorID) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertPrice переводит цену в валюту фида по точному курсу из настроек
+func convertPrice(price entity.Money, currency string, rates map[string]string) (entity.Money, error) {
+	if price.Currency() == currency {
+		return price, nil
+	}
+	rate, ok := rates[price.Currency()]
+	if !ok {
+		return entity.Money{}, fmt.Errorf("no exchange rate from %s to %s", price.Currency(), currency)
+	}
+	r, err := entity.ParseDecimal(rate)
+	if err != nil {
+		return entity.Money{}, err
+	}
+	return price.Convert(currency, r)
+}
+
+// trackingVars возвращает значения плейсхолдеров трекинг-параметров для товара
+func trackingVars(feed dto.FeedRequest, tree *entity.CategoryTree, prod *entity.Product) map[string]string {
+	vars := map[string]string{
+		"feed":        feed.Name,
+		"offer_id":    prod.ID,
+		"category_id": prod.CategoryID,
+	}
+	if cat, ok := tree.Get(prod.CategoryID); ok {
+		vars["category_name"] = cat.Name
+	}
+	if prod.Vendor != nil {
+		vars["vendor"] = *prod.Vendor
+	}
+	return vars
+}
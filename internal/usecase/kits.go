@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"fmt"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// kitComponentParam — название характеристики со списком товаров комплекта
+const kitComponentParam = "Состав комплекта"
+
+// kitComponents возвращает товары комплекта из фида.
+// Комплект пропускается, если товар отсутствует в фиде или недоступен.
+func (uc *ExportCatalogUseCase) kitComponents(
+	feed dto.FeedRequest,
+	kit entity.ProductKit,
+	byID map[string]*entity.Product,
+) ([]*entity.Product, bool) {
+	if len(kit.ProductIDs) < 2 {
+		uc.logger.Warn(fmt.Sprintf("Feed %s: kit %s skipped: at least two products are required", feed.Name, kit.ID))
+		return nil, false
+	}
+
+	components := make([]*entity.Product, 0, len(kit.ProductIDs))
+	for _, productID := range kit.ProductIDs {
+		prod, ok := byID[productID]
+		if !ok {
+			uc.logger.Debug(fmt.Sprintf("Feed %s: kit %s skipped: product %s is not exported", feed.Name, kit.ID, productID))
+			return nil, false
+		}
+		if !prod.Available {
+			uc.logger.Debug(fmt.Sprintf("Feed %s: kit %s skipped: product %s is unavailable", feed.Name, kit.ID, productID))
+			return nil, false
+		}
+		components = append(components, prod)
+	}
+	return components, true
+}
+
+// indexProducts индексирует товары фида по ID
+func indexProducts(products []entity.Product) map[string]*entity.Product {
+	byID := make(map[string]*entity.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+	return byID
+}
+
+// buildBundles формирует предложения-комплекты: цена — сумма исходных цен товаров,
+// изображения и характеристики собираются из состава комплекта. Товары должны быть
+// ещё не подготовлены для фида: конвертация, правила ценообразования и трекинг
+// применяются к комплекту как к обычному предложению.
+func (uc *ExportCatalogUseCase) buildBundles(
+	feed dto.FeedRequest,
+	kits []entity.ProductKit,
+	products []entity.Product,
+) ([]entity.Product, error) {
+	byID := indexProducts(products)
+
+	bundles := make([]entity.Product, 0, len(kits))
+	for _, kit := range kits {
+		components, ok := uc.kitComponents(feed, kit, byID)
+		if !ok {
+			continue
+		}
+
+		main := components[0]
+		bundle := entity.Product{
+			ID:         "kit-" + kit.ID,
+			Name:       kit.Name,
+			StatusID:   main.StatusID,
+			CategoryID: main.CategoryID,
+			Price:      main.Price,
+			URL:        main.URL,
+			Vendor:     main.Vendor,
+			Available:  true,
+		}
+		if bundle.Name == "" {
+			bundle.Name = main.Name
+		}
+		if kit.Description != "" {
+			description := kit.Description
+			bundle.Description = &description
+		}
+
+		for i, component := range components {
+			if i > 0 {
+				price, err := bundle.Price.Add(component.Price)
+				if err != nil {
+					return nil, fmt.Errorf("kit %s: %w", kit.ID, err)
+				}
+				bundle.Price = price
+			}
+			// Производитель указывается, только если он общий для всего комплекта
+			if bundle.Vendor != nil && (component.Vendor == nil || *component.Vendor != *bundle.Vendor) {
+				bundle.Vendor = nil
+			}
+			bundle.Images = append(bundle.Images, component.Images...)
+			bundle.Params = append(bundle.Params, entity.Param{Name: kitComponentParam, Value: component.Name})
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	uc.logger.Info(fmt.Sprintf("Feed %s: %d of %d kits exported as bundles", feed.Name, len(bundles), len(kits)))
+	return bundles, nil
+}
+
+// buildGiftPromos формирует акции «подарок при покупке»:
+// первый товар комплекта покупается, остальные выдаются в подарок.
+func (uc *ExportCatalogUseCase) buildGiftPromos(
+	feed dto.FeedRequest,
+	kits []entity.ProductKit,
+	products []entity.Product,
+) []entity.Promo {
+	byID := indexProducts(products)
+
+	promos := make([]entity.Promo, 0, len(kits))
+	for _, kit := range kits {
+		components, ok := uc.kitComponents(feed, kit, byID)
+		if !ok {
+			continue
+		}
+
+		promo := entity.Promo{
+			ID:               "kit-" + kit.ID,
+			Type:             entity.PromoTypeGift,
+			Description:      kit.Name,
+			OfferIDs:         []string{components[0].ID},
+			RequiredQuantity: 1,
+		}
+		if kit.Description != "" {
+			promo.Description = kit.Description
+		}
+		for _, gift := range components[1:] {
+			promo.GiftOfferIDs = append(promo.GiftOfferIDs, gift.ID)
+		}
+
+		promos = append(promos, promo)
+	}
+
+	uc.logger.Info(fmt.Sprintf("Feed %s: %d of %d kits exported as gifts", feed.Name, len(promos), len(kits)))
+	return promos
+}
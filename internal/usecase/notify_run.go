@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// maxSkipReasons — сколько самых частых причин пропуска выводится в тексте уведомления
+const maxSkipReasons = 5
+
+// Notifier определяет интерфейс канала уведомлений
+type Notifier interface {
+	Notify(ctx context.Context, notification dto.Notification) error
+}
+
+// NotifyRunUseCase реализует сценарий уведомления о результатах запуска
+type NotifyRunUseCase struct {
+	notifiers map[string]Notifier // каналы по имени из настроек
+	logger    Logger
+}
+
+// NewNotifyRunUseCase создаёт новый экземпляр use case
+func NewNotifyRunUseCase(notifiers map[string]Notifier, logger Logger) *NotifyRunUseCase {
+	return &NotifyRunUseCase{
+		notifiers: notifiers,
+		logger:    logger,
+	}
+}
+
+// Execute формирует сводку запуска и отправляет её по маршрутам. Ошибка запуска
+// сообщается всем маршрутам, предупреждения — маршрутам соответствующих фидов.
+// Пропуски товаров считаются предупреждением только сверх порогов запроса или
+// при появлении причин, которых не было в предыдущем запуске.
+// Ошибки отдельных каналов не прерывают отправку в остальные.
+func (uc *NotifyRunUseCase) Execute(ctx context.Context, req dto.NotifyRequest) error {
+	if req.Report == nil {
+		return nil
+	}
+	summaries := summarizeFeeds(req)
+	runWarnings := runSkipWarnings(req)
+
+	var errs []error
+	for _, route := range req.Routes {
+		notifier, ok := uc.notifiers[route.Sink]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown notification sink %q", route.Sink))
+			continue
+		}
+
+		var feeds []dto.FeedSummary
+		level := dto.NotificationSuccess
+		// Товары, пропущенные до формирования фидов, касаются всех маршрутов
+		if len(runWarnings) > 0 {
+			level = dto.NotificationWarning
+		}
+		for _, summary := range summaries {
+			if !route.Matches(summary.Name) {
+				continue
+			}
+			feeds = append(feeds, summary)
+			if len(summary.Warnings) > 0 {
+				level = dto.NotificationWarning
+			}
+		}
+		if req.Report.Error != "" {
+			level = dto.NotificationFailure
+		}
+		if !route.Accepts(level) {
+			uc.logger.Debug(fmt.Sprintf("Notification route %s skipped for %s run", route.Sink, level))
+			continue
+		}
+
+		if err := notifier.Notify(ctx, buildNotification(req.Report, level, runWarnings, feeds)); err != nil {
+			errs = append(errs, fmt.Errorf("sink %s: %w", route.Sink, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// summarizeFeeds составляет сводки фидов и находит аномалии по сравнению с предыдущим запуском
+func summarizeFeeds(req dto.NotifyRequest) []dto.FeedSummary {
+	previous := make(map[string]dto.FeedReport)
+	if req.Previous != nil {
+		for _, feed := range req.Previous.Feeds {
+			previous[feed.Name] = feed
+		}
+	}
+
+	summaries := make([]dto.FeedSummary, 0, len(req.Report.Feeds))
+	for _, feed := range req.Report.Feeds {
+		summary := dto.FeedSummary{
+			Name:     feed.Name,
+			Offers:   feed.Offers,
+			Skipped:  len(feed.Skipped),
+			Unmapped: len(feed.UnmappedCategories),
+		}
+		summary.SkipReasons = countSkipCodes(feed.Skipped)
+
+		prev, hasPrevious := previous[feed.Name]
+		if hasPrevious {
+			summary.PreviousOffers = &prev.Offers
+			if drop := offerDropPercent(prev.Offers, feed.Offers); req.MaxOfferDropPercent > 0 && drop >= req.MaxOfferDropPercent {
+				summary.Warnings = append(summary.Warnings, fmt.Sprintf("offers dropped from %d to %d (-%.1f%%)", prev.Offers, feed.Offers, drop))
+			}
+		}
+		if feed.Offers == 0 {
+			summary.Warnings = append(summary.Warnings, "feed has no offers")
+		}
+		summary.Warnings = append(summary.Warnings, skipWarnings(req, feed.Skipped, prev.Skipped, hasPrevious, feed.Offers+len(feed.Skipped))...)
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// runSkipWarnings возвращает предупреждения о товарах, не попавших в выгрузку до формирования фидов
+func runSkipWarnings(req dto.NotifyRequest) []string {
+	var previous []dto.SkippedOffer
+	if req.Previous != nil {
+		previous = req.Previous.Skipped
+	}
+	fetched := req.Report.Products + len(req.Report.Skipped)
+	return skipWarnings(req, req.Report.Skipped, previous, req.Previous != nil, fetched)
+}
+
+// skipWarnings возвращает предупреждения о пропусках сверх порогов запроса и о причинах
+// пропуска, которых не было в предыдущем запуске. total — число товаров вместе с пропущенными
+func skipWarnings(req dto.NotifyRequest, skipped, previous []dto.SkippedOffer, hasPrevious bool, total int) []string {
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	percent := float64(len(skipped)) * 100 / float64(total)
+	if (req.MaxSkipped > 0 && len(skipped) > req.MaxSkipped) ||
+		(req.MaxSkippedPercent > 0 && percent > req.MaxSkippedPercent) {
+		warnings = append(warnings, fmt.Sprintf("%d of %d products skipped (%.1f%%)", len(skipped), total, percent))
+	}
+
+	if hasPrevious {
+		known := countSkipCodes(previous)
+		var added []string
+		for code := range countSkipCodes(skipped) {
+			if _, ok := known[code]; !ok {
+				added = append(added, code)
+			}
+		}
+		if len(added) > 0 {
+			sort.Strings(added)
+			warnings = append(warnings, "new skip reasons: "+strings.Join(added, ", "))
+		}
+	}
+	return warnings
+}
+
+// countSkipCodes считает пропущенные товары по кодам причин
+func countSkipCodes(skipped []dto.SkippedOffer) map[string]int {
+	if len(skipped) == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, s := range skipped {
+		counts[string(s.Code)]++
+	}
+	return counts
+}
+
+// offerDropPercent возвращает падение числа предложений в процентах (0, если числа не уменьшились)
+func offerDropPercent(previous, current int) float64 {
+	if previous <= 0 || current >= previous {
+		return 0
+	}
+	return float64(previous-current) * 100 / float64(previous)
+}
+
+// buildNotification формирует уведомление со сводкой запуска и фидов маршрута
+func buildNotification(report *dto.ExportReport, level dto.NotificationLevel, warnings []string, feeds []dto.FeedSummary) dto.Notification {
+	notification := dto.Notification{
+		RunID:      report.RunID,
+		Level:      level,
+		StartedAt:  report.StartedAt,
+		Duration:   report.FinishedAt.Sub(report.StartedAt).Round(time.Second).String(),
+		Products:   report.Products,
+		Categories: report.Categories,
+		Error:      report.Error,
+		Skipped:    len(report.Skipped),
+		Warnings:   warnings,
+		Feeds:      feeds,
+
+		SkipReasons: countSkipCodes(report.Skipped),
+	}
+	switch level {
+	case dto.NotificationFailure:
+		notification.Title = "Feed export failed"
+	case dto.NotificationWarning:
+		notification.Title = "Feed export completed with warnings"
+	default:
+		notification.Title = "Feed export completed"
+	}
+
+	var b strings.Builder
+	b.WriteString(notification.Title + "\n")
+	if report.Error != "" {
+		b.WriteString("Error: " + report.Error + "\n")
+	}
+	if report.RunID != "" {
+		fmt.Fprintf(&b, "Run: %s\n", report.RunID)
+	}
+	fmt.Fprintf(&b, "Started: %s, duration %s\n", report.StartedAt.Format("2006-01-02 15:04:05 MST"), notification.Duration)
+	fmt.Fprintf(&b, "Products: %d, categories: %d\n", report.Products, report.Categories)
+	if notification.Skipped > 0 {
+		fmt.Fprintf(&b, "Skipped before feeds were built: %d\n", notification.Skipped)
+	}
+	for _, warning := range warnings {
+		b.WriteString("  - " + warning + "\n")
+	}
+	for _, code := range topReasons(notification.SkipReasons, maxSkipReasons) {
+		fmt.Fprintf(&b, "    %s: %d\n", code, notification.SkipReasons[code])
+	}
+
+	for _, feed := range feeds {
+		b.WriteString("\n" + feed.Name + ": " + fmt.Sprint(feed.Offers) + " offers")
+		if feed.PreviousOffers != nil {
+			fmt.Fprintf(&b, " (previous run: %d, %+d)", *feed.PreviousOffers, feed.Offers-*feed.PreviousOffers)
+		}
+		b.WriteString("\n")
+		for _, warning := range feed.Warnings {
+			b.WriteString("  - " + warning + "\n")
+		}
+		if feed.Unmapped > 0 {
+			fmt.Fprintf(&b, "  %d categories not mapped\n", feed.Unmapped)
+		}
+		for _, reason := range topReasons(feed.SkipReasons, maxSkipReasons) {
+			fmt.Fprintf(&b, "    %s: %d\n", reason, feed.SkipReasons[reason])
+		}
+	}
+	notification.Text = b.String()
+	return notification
+}
+
+// topReasons возвращает самые частые причины пропуска
+func topReasons(reasons map[string]int, limit int) []string {
+	keys := make([]string, 0, len(reasons))
+	for reason := range reasons {
+		keys = append(keys, reason)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if reasons[keys[i]] != reasons[keys[j]] {
+			return reasons[keys[i]] > reasons[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
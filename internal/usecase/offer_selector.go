@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// OfferSelector — writer, формат которого принимает только часть товаров
+// (например, привязанные к каталогу маркетплейса)
+type OfferSelector interface {
+	CatalogWriter
+	// Accept проверяет товар и возвращает причину, если он не может быть выгружен
+	Accept(prod *entity.Product, tree *entity.CategoryTree) (bool, string)
+}
+
+// selectOffers отбирает товары, подходящие формату writer'а; остальные попадают в отчёт.
+// Товары категорий без соответствия отмечаются отдельным кодом причины
+func selectOffers(writer CatalogWriter, tree *entity.CategoryTree, products []entity.Product, unmapped []dto.UnmappedCategory) ([]entity.Product, []dto.SkippedOffer) {
+	selector, ok := writer.(OfferSelector)
+	if !ok {
+		return products, nil
+	}
+
+	unmappedIDs := make(map[string]bool, len(unmapped))
+	for _, cat := range unmapped {
+		unmappedIDs[cat.CategoryID] = true
+	}
+
+	selected := make([]entity.Product, 0, len(products))
+	var skipped []dto.SkippedOffer
+	for i := range products {
+		if accepted, reason := selector.Accept(&products[i], tree); !accepted {
+			code := dto.SkipFormatRejected
+			if unmappedIDs[products[i].CategoryID] {
+				code = dto.SkipUnmappedCategory
+			}
+			skipped = append(skipped, newSkippedOffer(&products[i], code, reason))
+			continue
+		}
+		selected = append(selected, products[i])
+	}
+	return selected, skipped
+}
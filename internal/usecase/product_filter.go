@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// ProductFilterWriter — writer, формат которого отбирает товары фильтром API
+// (например, Shop.by по ProductFilter.shopByClassification)
+type ProductFilterWriter interface {
+	CatalogWriter
+	// ProductFilter возвращает фильтр товаров фида; пустой фильтр — все товары
+	ProductFilter() dto.ProductFilter
+}
+
+// FilteredProductSource — источник данных, отбирающий товары фильтром API
+type FilteredProductSource interface {
+	GetProductIDs(ctx context.Context, statusID int, filter dto.ProductFilter) ([]string, error)
+}
+
+// productFilter возвращает фильтр API для формата writer'а
+func productFilter(writer CatalogWriter) (dto.ProductFilter, bool) {
+	filterWriter, ok := writer.(ProductFilterWriter)
+	if !ok {
+		return dto.ProductFilter{}, false
+	}
+	filter := filterWriter.ProductFilter()
+	return filter, !filter.IsEmpty()
+}
+
+// filterProducts оставляет товары, которые вернул фильтр API; остальные попадают в отчёт
+func filterProducts(products []entity.Product, ids map[string]bool, filter dto.ProductFilter) ([]entity.Product, []dto.SkippedOffer) {
+	selected := make([]entity.Product, 0, len(products))
+	var skipped []dto.SkippedOffer
+	for i := range products {
+		if !ids[products[i].ID] {
+			skipped = append(skipped, newSkippedOffer(&products[i], dto.SkipFormatRejected, "not matched by API filter "+filter.String()))
+			continue
+		}
+		selected = append(selected, products[i])
+	}
+	return selected, skipped
+}
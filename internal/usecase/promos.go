@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Допустимый размер процентной скидки промокода в Яндекс.Маркете
+const (
+	minPromoPercent = 5
+	maxPromoPercent = 95
+)
+
+// buildPromos превращает действующие промокоды в акции фида.
+// Страницы промокода сопоставляются с товарами и категориями фида по pageId.
+func (uc *ExportCatalogUseCase) buildPromos(
+	feed dto.FeedRequest,
+	codes []entity.DiscountCode,
+	categories []entity.Category,
+	products []entity.Product,
+	shopCurrency string,
+	now time.Time,
+) ([]entity.Promo, error) {
+	offerByPage := make(map[string]string, len(products))
+	for _, prod := range products {
+		if prod.PageID != "" {
+			offerByPage[prod.PageID] = prod.ID
+		}
+	}
+	categoryByPage := make(map[string]string, len(categories))
+	var rootCategories []string
+	for _, cat := range categories {
+		if cat.PageID != "" {
+			categoryByPage[cat.PageID] = cat.ID
+		}
+		if cat.IsRoot() {
+			rootCategories = append(rootCategories, cat.ID)
+		}
+	}
+
+	promos := make([]entity.Promo, 0, len(codes))
+	for _, code := range codes {
+		if !code.IsActiveAt(now) {
+			uc.logger.Debug(fmt.Sprintf("Feed %s: discount code %s is not active", feed.Name, code.ID))
+			continue
+		}
+
+		promo := entity.Promo{
+			ID:          "promo-" + code.ID,
+			Type:        entity.PromoTypePromoCode,
+			Code:        code.Code,
+			Description: code.Name,
+			Unit:        code.Unit,
+			StartDate:   code.DateFrom,
+			EndDate:     code.DateTo,
+		}
+		if promo.Description == "" {
+			promo.Description = code.Comment
+		}
+		if promo.EndDate != nil {
+			end := promo.EndDate.Add(24*time.Hour - time.Second)
+			promo.EndDate = &end
+		}
+
+		switch code.Unit {
+		case entity.DiscountUnitPercent:
+			if code.Discount < minPromoPercent || code.Discount > maxPromoPercent {
+				uc.logger.Warn(fmt.Sprintf("Feed %s: discount code %s skipped: percent %s is outside %d-%d",
+					feed.Name, code.ID, strconv.FormatFloat(code.Discount, 'f', -1, 64), minPromoPercent, maxPromoPercent))
+				continue
+			}
+			promo.Percent = code.Discount
+		default:
+			discount, err := entity.MoneyFromFloat(code.Discount, shopCurrency)
+			if err != nil {
+				uc.logger.Warn(fmt.Sprintf("Feed %s: discount code %s skipped: %v", feed.Name, code.ID, err))
+				continue
+			}
+			amount, err := convertPrice(discount, feed.TargetCurrency(shopCurrency), feed.Rates)
+			if err != nil {
+				return nil, fmt.Errorf("discount code %s: %w", code.ID, err)
+			}
+			promo.Amount = amount
+		}
+
+		if code.AllProducts {
+			promo.CategoryIDs = rootCategories
+		} else {
+			for _, pageID := range code.PageIDs {
+				if offerID, ok := offerByPage[pageID]; ok {
+					promo.OfferIDs = append(promo.OfferIDs, offerID)
+				} else if categoryID, ok := categoryByPage[pageID]; ok {
+					promo.CategoryIDs = append(promo.CategoryIDs, categoryID)
+				}
+			}
+		}
+		if len(promo.OfferIDs) == 0 && len(promo.CategoryIDs) == 0 {
+			uc.logger.Debug(fmt.Sprintf("Feed %s: discount code %s covers no exported offers", feed.Name, code.ID))
+			continue
+		}
+
+		promos = append(promos, promo)
+	}
+
+	return promos, nil
+}
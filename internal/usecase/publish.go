@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// Publisher определяет интерфейс публикации записанного файла фида.
+// Publish возвращает адрес опубликованного файла.
+type Publisher interface {
+	Publish(ctx context.Context, localPath string, target dto.PublishTarget) (string, error)
+}
+
+// feedFiles возвращает файлы фида в порядке публикации: части фида, затем манифест (или индекс),
+// чтобы потребитель, читающий манифест, находил все перечисленные в нём части
+func feedFiles(feed dto.FeedReport) []string {
+	if len(feed.Parts) == 0 {
+		return []string{feed.OutputPath}
+	}
+	files := make([]string, 0, len(feed.Parts)+1)
+	for _, part := range feed.Parts {
+		files = append(files, part.Path)
+	}
+	return append(files, feed.Manifest)
+}
+
+// publishFeed публикует файлы фида по всем целям и добавляет их в отчёт
+func (uc *ExportCatalogUseCase) publishFeed(ctx context.Context, feed dto.FeedRequest, feedReport *dto.FeedReport) error {
+	for _, target := range feed.Publish {
+		publisher := uc.publishers[target.Publisher]
+		for _, path := range feedFiles(*feedReport) {
+			location, err := publisher.Publish(ctx, path, target)
+			if err != nil {
+				return fmt.Errorf("publisher %s: %w", target.Publisher, err)
+			}
+			uc.logger.Info(fmt.Sprintf("Feed %s: published %s", feed.Name, location))
+			feedReport.Published = append(feedReport.Published, dto.PublishedFile{
+				Publisher: target.Publisher,
+				Location:  location,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import "beseller-yml-exporter/internal/domain/entity"
+
+// ReviewsWriter — writer, формату которого нужны отзывы о товарах
+type ReviewsWriter interface {
+	CatalogWriter
+	UsesReviews() bool
+}
+
+// usesReviews проверяет, нужны ли writer'у отзывы
+func usesReviews(writer CatalogWriter) bool {
+	rw, ok := writer.(ReviewsWriter)
+	return ok && rw.UsesReviews()
+}
+
+// linkReviews сопоставляет принятые отзывы с товарами фида по странице товара.
+// Отзывы о товарах, не попавших в фид, и отзывы без оценки 1–5 отбрасываются.
+func linkReviews(reviews []entity.Review, products []entity.Product) []entity.Review {
+	productByPage := make(map[string]string, len(products))
+	for _, prod := range products {
+		if prod.PageID != "" {
+			productByPage[prod.PageID] = prod.ID
+		}
+	}
+
+	linked := make([]entity.Review, 0, len(reviews))
+	for _, rev := range reviews {
+		productID, ok := productByPage[rev.PageID]
+		if !ok || !rev.Accepted || !rev.HasValidMark() {
+			continue
+		}
+		rev.ProductID = productID
+		linked = append(linked, rev)
+	}
+	return linked
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// FileInspector определяет интерфейс получения размера и контрольной суммы файла фида
+type FileInspector interface {
+	Inspect(path string) (dto.OutputFile, error)
+}
+
+// APIStatsSource — источник данных, ведущий статистику обращений к API
+type APIStatsSource interface {
+	APIStats() dto.APIStats
+}
+
+// newRunID возвращает идентификатор запуска: время начала и случайный суффикс
+func newRunID(started time.Time) string {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return started.UTC().Format("20060102T150405Z")
+	}
+	return started.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+}
+
+// newSkippedOffer описывает пропущенный товар для отчёта
+func newSkippedOffer(prod *entity.Product, code dto.SkipReason, reason string) dto.SkippedOffer {
+	return dto.SkippedOffer{
+		ProductID:  prod.ID,
+		Name:       prod.Name,
+		CategoryID: prod.CategoryID,
+		URL:        prod.URL,
+		Code:       code,
+		Reason:     reason,
+	}
+}
+
+// linkCheckSkips возвращает товары, исключённые проверкой ссылок, с первой недоступной ссылкой
+func linkCheckSkips(products []entity.Product, linkReport dto.LinkCheckReport) []dto.SkippedOffer {
+	byID := make(map[string]*entity.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	var skipped []dto.SkippedOffer
+	for _, issue := range linkReport.Products {
+		prod, ok := byID[issue.ProductID]
+		if !issue.Excluded || !ok || len(issue.Broken) == 0 {
+			continue
+		}
+		res := issue.Broken[0]
+		problem := res.Error
+		if problem == "" {
+			problem = fmt.Sprintf("HTTP %d", res.StatusCode)
+		}
+		skipped = append(skipped, newSkippedOffer(prod, dto.SkipBrokenLink, fmt.Sprintf("%s %s: %s", res.Kind, res.URL, problem)))
+	}
+	return skipped
+}
+
+// categoryCounts считает выгружаемые и пропущенные товары по категориям
+func categoryCounts(tree *entity.CategoryTree, products []entity.Product, skipped []dto.SkippedOffer) []dto.CategoryCount {
+	counts := make(map[string]*dto.CategoryCount)
+	get := func(categoryID string) *dto.CategoryCount {
+		count, ok := counts[categoryID]
+		if !ok {
+			count = &dto.CategoryCount{CategoryID: categoryID}
+			if cat, found := tree.Get(categoryID); found {
+				count.Name = cat.Name
+			}
+			counts[categoryID] = count
+		}
+		return count
+	}
+	for i := range products {
+		get(products[i].CategoryID).Products++
+	}
+	for _, s := range skipped {
+		get(s.CategoryID).Skipped++
+	}
+
+	result := make([]dto.CategoryCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CategoryID < result[j].CategoryID })
+	return result
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// FeedSplitter определяет интерфейс записи фида частями с манифестом
+type FeedSplitter interface {
+	WriteParts(write func(string, entity.Catalog) error, outputPath string, catalog entity.Catalog, split dto.SplitConfig) ([]dto.FeedPart, string, error)
+}
+
+// PartsWriter — writer, формат которого сам разбивает вывод на файлы
+// (например, карта сайта с индексом частей)
+type PartsWriter interface {
+	CatalogWriter
+	// WriteParts записывает каталог и возвращает части; outputPath при этом
+	// становится индексом частей. Пустой список — вывод уместился в outputPath
+	WriteParts(outputPath string, catalog entity.Catalog) ([]dto.FeedPart, error)
+}
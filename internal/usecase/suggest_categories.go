@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"beseller-yml-exporter/internal/domain/entity"
+	"beseller-yml-exporter/internal/domain/repository"
+	"beseller-yml-exporter/internal/usecase/dto"
+)
+
+// CategorySuggester определяет интерфейс подбора категорий площадки по названиям
+type CategorySuggester interface {
+	Suggest(tree *entity.CategoryTree) []dto.CategorySuggestion
+	Save(path string, suggestions []dto.CategorySuggestion) error
+}
+
+// SuggestCategoriesUseCase реализует сценарий подбора соответствий категорий
+type SuggestCategoriesUseCase struct {
+	catalogRepo repository.CatalogRepository
+	suggester   CategorySuggester
+	logger      Logger
+}
+
+// NewSuggestCategoriesUseCase создаёт новый экземпляр use case
+func NewSuggestCategoriesUseCase(
+	catalogRepo repository.CatalogRepository,
+	suggester CategorySuggester,
+	logger Logger,
+) *SuggestCategoriesUseCase {
+	return &SuggestCategoriesUseCase{
+		catalogRepo: catalogRepo,
+		suggester:   suggester,
+		logger:      logger,
+	}
+}
+
+// Execute подбирает категории для категорий без соответствия и сохраняет таблицу
+func (uc *SuggestCategoriesUseCase) Execute(ctx context.Context, req dto.SuggestCategoriesRequest) error {
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	uc.logger.Info("Fetching categories...")
+	categories, err := uc.catalogRepo.GetCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch categories: %w", err)
+	}
+
+	suggestions := uc.suggester.Suggest(entity.NewCategoryTree(categories))
+	accepted := 0
+	for _, s := range suggestions {
+		if s.Accepted {
+			accepted++
+			uc.logger.Info(fmt.Sprintf("%s %q → %s %q (score %.2f)", s.CategoryID, s.Category, s.Value, s.Label, s.Score))
+		} else {
+			uc.logger.Warn(fmt.Sprintf("%s %q: no confident match (best %q, score %.2f)", s.CategoryID, s.Category, s.Label, s.Score))
+		}
+	}
+
+	if err := uc.suggester.Save(req.OutputPath, suggestions); err != nil {
+		return fmt.Errorf("failed to save category map: %w", err)
+	}
+
+	uc.logger.Info(fmt.Sprintf("Category map saved: %s (unmapped=%d, suggested=%d)", req.OutputPath, len(suggestions), accepted))
+	return nil
+}